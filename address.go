@@ -0,0 +1,281 @@
+package countries
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultAddressFormat is the address format used for countries without an
+// AddressFormat.
+const DefaultAddressFormat = "{{recipient}}\n{{street}}\n{{postalcode}} {{city}}\n{{country}}"
+
+// Address is a postal address. StreetLines holds the street address lines, like
+// the street and the house number or the apartment. Region is a subdivision
+// code or name and CountryCode is the country alpha2 code.
+type Address struct {
+	Recipient         string
+	Organization      string
+	StreetLines       []string
+	DependentLocality string
+	City              string
+	Region            string
+	PostalCode        string
+	CountryCode       string
+	SortingCode       string
+}
+
+// AddressField identifies a field of an Address.
+type AddressField string
+
+// Address fields.
+const (
+	AddressFieldRecipient         AddressField = "recipient"
+	AddressFieldOrganization      AddressField = "organization"
+	AddressFieldStreet            AddressField = "street"
+	AddressFieldDependentLocality AddressField = "dependent_locality"
+	AddressFieldCity              AddressField = "city"
+	AddressFieldRegion            AddressField = "region"
+	AddressFieldPostalCode        AddressField = "postalcode"
+	AddressFieldCountry           AddressField = "country"
+	AddressFieldSortingCode       AddressField = "sorting_code"
+)
+
+// Value returns the value of the field f of the address. Street lines are
+// joined with a newline.
+func (a Address) Value(f AddressField) string {
+	switch f {
+	case AddressFieldRecipient:
+		return a.Recipient
+	case AddressFieldOrganization:
+		return a.Organization
+	case AddressFieldStreet:
+		return strings.Join(a.StreetLines, "\n")
+	case AddressFieldDependentLocality:
+		return a.DependentLocality
+	case AddressFieldCity:
+		return a.City
+	case AddressFieldRegion:
+		return a.Region
+	case AddressFieldPostalCode:
+		return a.PostalCode
+	case AddressFieldCountry:
+		return a.CountryCode
+	case AddressFieldSortingCode:
+		return a.SortingCode
+	}
+	return ""
+}
+
+// AddressFieldSchema describes how a country uses an address field.
+type AddressFieldSchema struct {
+	Field    AddressField
+	Required bool
+	Label    string
+}
+
+// AddressSchema describes the fields of the addresses of a country. Fields
+// lists the used fields in the order they appear in the formatted address and
+// Lines groups them by line.
+type AddressSchema struct {
+	Fields []AddressFieldSchema
+	Lines  [][]AddressField
+}
+
+// Field returns the schema of the field f. If the field is not used returns
+// false.
+func (s AddressSchema) Field(f AddressField) (AddressFieldSchema, bool) {
+	for _, field := range s.Fields {
+		if field.Field == f {
+			return field, true
+		}
+	}
+	return AddressFieldSchema{}, false
+}
+
+// Uses returns true if the field f is part of the country addresses.
+func (s AddressSchema) Uses(f AddressField) bool {
+	_, found := s.Field(f)
+	return found
+}
+
+// Required returns true if the field f is mandatory in the country addresses.
+func (s AddressSchema) Required(f AddressField) bool {
+	field, _ := s.Field(f)
+	return field.Required
+}
+
+// Label returns the label of the field f, like "State" or "ZIP code". If the
+// field is not used returns an empty string.
+func (s AddressSchema) Label(f AddressField) string {
+	field, _ := s.Field(f)
+	return field.Label
+}
+
+// addressSegment is a piece of an address format line: either a literal text
+// or a placeholder. If short is true the placeholder is {{region_short}}.
+type addressSegment struct {
+	literal string
+	field   AddressField
+	short   bool
+}
+
+var addressPlaceholder = regexp.MustCompile(`{{(\w+)}}`)
+
+// addressFormat returns the country AddressFormat or DefaultAddressFormat.
+func (c *Country) addressFormat() string {
+	if c.AddressFormat == "" {
+		return DefaultAddressFormat
+	}
+	return c.AddressFormat
+}
+
+// parseAddressFormat splits an address format into lines of segments.
+func parseAddressFormat(format string) [][]addressSegment {
+	var lines [][]addressSegment
+	for _, line := range strings.Split(format, "\n") {
+		var segments []addressSegment
+		last := 0
+		for _, m := range addressPlaceholder.FindAllStringSubmatchIndex(line, -1) {
+			if m[0] > last {
+				segments = append(segments, addressSegment{literal: line[last:m[0]]})
+			}
+			name := line[m[2]:m[3]]
+			switch name {
+			case "region_short":
+				segments = append(segments, addressSegment{field: AddressFieldRegion, short: true})
+			default:
+				segments = append(segments, addressSegment{field: AddressField(name)})
+			}
+			last = m[1]
+		}
+		if last < len(line) {
+			segments = append(segments, addressSegment{literal: line[last:]})
+		}
+		lines = append(lines, segments)
+	}
+	return lines
+}
+
+// addressRegionRequired lists the countries whose addresses are not
+// deliverable without the region.
+var addressRegionRequired = map[string]bool{
+	"AU": true, "BR": true, "CA": true, "CN": true, "ES": true, "HK": true,
+	"IN": true, "IT": true, "JP": true, "MX": true, "US": true,
+}
+
+// addressRegionLabels overrides the region label derived from the subdivision
+// types.
+var addressRegionLabels = map[string]string{
+	"GB": "County",
+	"IE": "County",
+}
+
+// addressPostalCodeLabels overrides the default "Postal code" label.
+var addressPostalCodeLabels = map[string]string{
+	"AU": "Postcode",
+	"GB": "Postcode",
+	"IE": "Eircode",
+	"IN": "PIN code",
+	"NZ": "Postcode",
+	"US": "ZIP code",
+	"ZA": "Postcode",
+}
+
+// AddressSchema returns the schema of the country addresses derived from its
+// AddressFormat (or DefaultAddressFormat). The organization is always an
+// optional field placed after the recipient. Recipient, street and city are
+// required, the postal code is required if the country has postal codes and
+// the region if the country addresses are not deliverable without it.
+func (c *Country) AddressSchema() AddressSchema {
+	var schema AddressSchema
+	add := func(f AddressField) {
+		if schema.Uses(f) {
+			return
+		}
+		schema.Fields = append(schema.Fields, AddressFieldSchema{
+			Field:    f,
+			Required: c.addressFieldRequired(f),
+			Label:    c.addressFieldLabel(f),
+		})
+	}
+	for _, segments := range parseAddressFormat(c.addressFormat()) {
+		var line []AddressField
+		for _, s := range segments {
+			if s.field == "" {
+				continue
+			}
+			add(s.field)
+			line = append(line, s.field)
+		}
+		if len(line) == 0 {
+			continue
+		}
+		schema.Lines = append(schema.Lines, line)
+		if line[0] == AddressFieldRecipient && !schema.Uses(AddressFieldOrganization) {
+			add(AddressFieldOrganization)
+			schema.Lines = append(schema.Lines, []AddressField{AddressFieldOrganization})
+		}
+	}
+	return schema
+}
+
+func (c *Country) addressFieldRequired(f AddressField) bool {
+	switch f {
+	case AddressFieldRecipient, AddressFieldStreet, AddressFieldCity, AddressFieldCountry:
+		return true
+	case AddressFieldPostalCode:
+		return c.HasPostalCode()
+	case AddressFieldRegion:
+		return addressRegionRequired[c.Alpha2]
+	}
+	return false
+}
+
+func (c *Country) addressFieldLabel(f AddressField) string {
+	switch f {
+	case AddressFieldRecipient:
+		return "Name"
+	case AddressFieldOrganization:
+		return "Organization"
+	case AddressFieldStreet:
+		return "Street address"
+	case AddressFieldDependentLocality:
+		return "Neighborhood"
+	case AddressFieldCity:
+		return "City"
+	case AddressFieldRegion:
+		if label, found := addressRegionLabels[c.Alpha2]; found {
+			return label
+		}
+		return c.regionLabel()
+	case AddressFieldPostalCode:
+		if label, found := addressPostalCodeLabels[c.Alpha2]; found {
+			return label
+		}
+		return "Postal code"
+	case AddressFieldCountry:
+		return "Country"
+	case AddressFieldSortingCode:
+		return "Sorting code"
+	}
+	return ""
+}
+
+// regionLabel returns the most common subdivision type of the country in a
+// human readable form, like "State" or "Prefecture".
+func (c *Country) regionLabel() string {
+	counts := make(map[SubdivisionType]int)
+	var best SubdivisionType
+	for _, code := range sortedSubdivisionCodes(c.Subdivisions) {
+		t := c.Subdivisions[code].Type
+		counts[t]++
+		if counts[t] > counts[best] {
+			best = t
+		}
+	}
+	if best == "" {
+		return "Region"
+	}
+	label := strings.ReplaceAll(string(best), "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,172 @@
+package countries
+
+import (
+	"strings"
+	"unicode"
+)
+
+// AddressFormatOptions changes how FormatAddressWithOptions renders an address.
+type AddressFormatOptions struct {
+	// SenderCountry is the alpha2 code of the sender country. If it is the
+	// address country the address is domestic and the country line is omitted.
+	SenderCountry string
+	// SingleLine joins the address lines with a comma instead of a newline,
+	// like in a list of addresses.
+	SingleLine bool
+	// Uppercase renders the whole address upper cased, as recommended by the
+	// Universal Postal Union for postal labels.
+	Uppercase bool
+	// Locale is the locale of the country name in the country line. If the
+	// country has no translation for the locale ISOShortName is used.
+	Locale string
+}
+
+// FormatAddressWithOptions returns the address formatted with the country
+// AddressFormat (or DefaultAddressFormat) template and the options opts. Empty
+// fields are removed together with the separators and the characters bound to
+// them, like "〒" before the JP postal code, so the result never has blank
+// lines or stray spaces. The organization, if any, goes on the line after the
+// recipient.
+func (c *Country) FormatAddressWithOptions(a Address, opts AddressFormatOptions) string {
+	separator := "\n"
+	if opts.SingleLine {
+		separator = ", "
+	}
+	lines := c.addressLines(a, opts, func(_ AddressField, value string) string {
+		return value
+	}, func(literal string) string {
+		return literal
+	})
+	result := strings.Join(lines, separator)
+	if opts.Uppercase {
+		result = strings.ToUpper(result)
+	}
+	return result
+}
+
+// addressRegionNames returns the name and the code of the address region. If
+// the region is not a known subdivision both are the region as is.
+func (c *Country) addressRegionNames(a Address) (string, string) {
+	region := strings.TrimSpace(a.Region)
+	if s := c.addressRegion(region); s.Code != "" {
+		return s.Name, s.Code
+	}
+	return region, region
+}
+
+// addressStreet returns the non empty street lines of a.
+func addressStreet(a Address) []string {
+	lines := make([]string, 0, len(a.StreetLines))
+	for _, line := range a.StreetLines {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// addressLines returns the non empty lines of the address formatted with the
+// country address format. markup is applied to each non empty field value and
+// escape to the literal text of the format.
+func (c *Country) addressLines(a Address, opts AddressFormatOptions, markup func(AddressField, string) string, escape func(string) string) []string {
+	regionName, regionShortName := c.addressRegionNames(a)
+	streetSeparator := "\n"
+	if opts.SingleLine {
+		streetSeparator = ", "
+	}
+	value := func(s addressSegment) string {
+		var v string
+		switch s.field {
+		case AddressFieldRegion:
+			v = regionName
+			if s.short {
+				v = regionShortName
+			}
+		case AddressFieldStreet:
+			v = strings.Join(addressStreet(a), streetSeparator)
+		case AddressFieldCountry:
+			if opts.SenderCountry != "" && normalizeCode(opts.SenderCountry) == c.Alpha2 {
+				return ""
+			}
+			v = c.Translations[opts.Locale]
+			if v == "" {
+				v = c.ISOShortName
+			}
+		default:
+			v = strings.TrimSpace(a.Value(s.field))
+		}
+		if v == "" {
+			return ""
+		}
+		return markup(s.field, v)
+	}
+
+	var lines []string
+	for _, segments := range parseAddressFormat(c.addressFormat()) {
+		for i := range segments {
+			segments[i].literal = escape(segments[i].literal)
+		}
+		if line := formatAddressLine(segments, value); line != "" {
+			lines = append(lines, line)
+		}
+		if len(segments) > 0 && segments[0].field == AddressFieldRecipient {
+			if organization := strings.TrimSpace(a.Organization); organization != "" {
+				lines = append(lines, markup(AddressFieldOrganization, organization))
+			}
+		}
+	}
+	return lines
+}
+
+// formatAddressLine renders a line of an address format skipping the empty
+// fields. Literals made of spaces and punctuation are separators, kept only
+// between two non empty fields; other literals are bound to the next field (or
+// to the previous one at the end of the line) and dropped with it.
+func formatAddressLine(segments []addressSegment, value func(addressSegment) string) string {
+	var b strings.Builder
+	var prefix, separator string
+	pendingSeparator := false
+	for i, s := range segments {
+		if s.field == "" {
+			if strings.TrimFunc(s.literal, isAddressSeparator) == "" {
+				separator = s.literal
+				pendingSeparator = b.Len() > 0
+				continue
+			}
+			if i == len(segments)-1 || !hasField(segments[i+1:]) {
+				if b.Len() > 0 && !pendingSeparator {
+					b.WriteString(s.literal)
+				}
+				continue
+			}
+			prefix += s.literal
+			continue
+		}
+		v := value(s)
+		if v == "" {
+			prefix = ""
+			continue
+		}
+		if pendingSeparator {
+			b.WriteString(separator)
+			pendingSeparator = false
+		}
+		b.WriteString(prefix)
+		b.WriteString(v)
+		prefix = ""
+	}
+	return b.String()
+}
+
+func hasField(segments []addressSegment) bool {
+	for _, s := range segments {
+		if s.field != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isAddressSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
@@ -0,0 +1,77 @@
+package countries_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAddressWithOptions(t *testing.T) {
+	us := countries.Get("US")
+	a := countries.Address{
+		Recipient:    "John Smith",
+		Organization: "ACME",
+		StreetLines:  []string{"1084 Nuzum Court", "Apt 2"},
+		City:         "Buffalo",
+		Region:       "New York",
+		PostalCode:   "14214",
+		CountryCode:  "US",
+	}
+	assert.Equal(t, "John Smith\nACME\n1084 Nuzum Court\nApt 2\nBuffalo NY 14214\nUnited States of America", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+	assert.Equal(t, "John Smith\nACME\n1084 Nuzum Court\nApt 2\nBuffalo NY 14214", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{SenderCountry: "us"}))
+	assert.Equal(t, "John Smith\nACME\n1084 Nuzum Court\nApt 2\nBuffalo NY 14214\nUnited States of America", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{SenderCountry: "IT"}))
+	assert.Equal(t, "John Smith, ACME, 1084 Nuzum Court, Apt 2, Buffalo NY 14214", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{SenderCountry: "US", SingleLine: true}))
+	assert.Equal(t, "JOHN SMITH\nACME\n1084 NUZUM COURT\nAPT 2\nBUFFALO NY 14214\nUNITED STATES OF AMERICA", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{Uppercase: true}))
+	assert.Equal(t, "John Smith\nACME\n1084 Nuzum Court\nApt 2\nBuffalo NY 14214\nStati Uniti", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{Locale: "it"}))
+	assert.Equal(t, "John Smith\nACME\n1084 Nuzum Court\nApt 2\nBuffalo NY 14214\nUnited States of America", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{Locale: "xx"}))
+
+	a = countries.Address{Recipient: "John Smith", StreetLines: []string{" ", "1084 Nuzum Court"}, City: "Buffalo", PostalCode: "14214"}
+	assert.Equal(t, "John Smith\n1084 Nuzum Court\nBuffalo 14214\nUnited States of America", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+	a = countries.Address{Recipient: "John Smith", Region: "NY"}
+	assert.Equal(t, "John Smith\nNY\nUnited States of America", us.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+
+	jp := countries.Get("JP")
+	a = countries.Address{Recipient: "Taro Yamada", StreetLines: []string{"1-1 Chiyoda"}, City: "Chiyoda-ku"}
+	assert.Equal(t, "Chiyoda-ku1-1 Chiyoda\nTaro Yamada\nJapan", jp.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+	a.PostalCode = "100-0001"
+	assert.Equal(t, "〒100-0001\nChiyoda-ku1-1 Chiyoda\nTaro Yamada\nJapan", jp.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+
+	// Countries without an address format use DefaultAddressFormat
+	ad := countries.Get("AD")
+	a = countries.Address{Recipient: "Enrico Pilotto", StreetLines: []string{"Carrer Major 1"}, City: "Andorra la Vella", PostalCode: "AD500"}
+	assert.Equal(t, "Enrico Pilotto\nCarrer Major 1\nAD500 Andorra la Vella\nAndorra", ad.FormatAddressWithOptions(a, countries.AddressFormatOptions{}))
+
+	for _, c := range countries.Data.All {
+		address := c.FormatAddressWithOptions(countries.Address{Recipient: "Enrico Pilotto"}, countries.AddressFormatOptions{SenderCountry: c.Alpha2})
+		assert.Equal(t, "Enrico Pilotto", address, fmt.Sprintf("Invalid formatted address for country %s", c.Alpha2))
+		address = c.FormatAddressWithOptions(countries.Address{City: "Acate", PostalCode: "97011"}, countries.AddressFormatOptions{})
+		for _, line := range strings.Split(address, "\n") {
+			assert.Equal(t, strings.TrimSpace(line), line, fmt.Sprintf("Invalid formatted address for country %s", c.Alpha2))
+			assert.NotContains(t, line, "  ", fmt.Sprintf("Invalid formatted address for country %s", c.Alpha2))
+			assert.NotEmpty(t, line, fmt.Sprintf("Invalid formatted address for country %s", c.Alpha2))
+		}
+	}
+}
+
+func ExampleCountry_FormatAddressWithOptions() {
+	c := countries.Get("IT")
+	a := countries.Address{
+		Recipient:   "Enrico Pilotto",
+		StreetLines: []string{"via Garibaldi 15"},
+		City:        "Acate",
+		Region:      "RG",
+		PostalCode:  "97011",
+		CountryCode: "IT",
+	}
+	fmt.Println(c.FormatAddressWithOptions(a, countries.AddressFormatOptions{SingleLine: true, Locale: "it"}))
+	fmt.Println(c.FormatAddressWithOptions(a, countries.AddressFormatOptions{Uppercase: true, SenderCountry: "FR"}))
+	// Output:
+	// Enrico Pilotto, via Garibaldi 15, 97011 Acate RG, Italia
+	// ENRICO PILOTTO
+	// VIA GARIBALDI 15
+	// 97011 ACATE RG
+	// ITALY
+}
@@ -0,0 +1,401 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidAddress is returned when a free text address cannot be split into
+// the address fields.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ParseAddress splits a free text address, like one pasted in a single field,
+// into the address fields following the lines of the country AddressFormat. The
+// lines are separated by newlines or, if the text is on a single line, by
+// commas. The postal code is located with the country PostalCodeFormat and the
+// region with the names and the codes of the country subdivisions; Region is
+// set to the subdivision code. A trailing line with the country name is
+// ignored. Lines exceeding the format go to StreetLines. If country is nil the
+// country is detected from the last line. It returns ErrInvalidAddress if the
+// country is unknown or the text has too few lines for the country format.
+func ParseAddress(text string, country *Country) (Address, error) {
+	lines := splitAddressText(text)
+	if len(lines) == 0 {
+		return Address{}, fmt.Errorf("%w: empty address", ErrInvalidAddress)
+	}
+	if country == nil {
+		country = addressCountry(lines[len(lines)-1], nil)
+		if country == nil {
+			return Address{}, fmt.Errorf("%w: unknown country %q", ErrInvalidAddress, lines[len(lines)-1])
+		}
+	}
+	if len(lines) > 1 && addressCountry(lines[len(lines)-1], country) != nil {
+		lines = lines[:len(lines)-1]
+	}
+
+	p := addressParser{
+		country:  country,
+		template: addressTemplate(country),
+		lines:    lines,
+		address:  Address{CountryCode: country.Alpha2},
+	}
+	if country.HasPostalCode() {
+		p.parsePostalCode()
+	}
+	p.parseRegion()
+	return p.parseLines()
+}
+
+// splitAddressText returns the trimmed non empty lines of text.
+func splitAddressText(text string) []string {
+	separator := "\n"
+	if !strings.Contains(strings.TrimSpace(text), "\n") {
+		separator = ","
+	}
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, separator) {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// addressCountry returns the country named or identified by the code line. If
+// want is not nil only want is returned.
+func addressCountry(line string, want *Country) *Country {
+	for _, m := range FindByName(line) {
+		if want == nil || m.Country == want {
+			return m.Country
+		}
+	}
+	if c := Lookup(line); c != nil && strings.ToUpper(line) == line && (want == nil || c == want) {
+		return c
+	}
+	return nil
+}
+
+// addressTemplate returns the lines of the country address format without the
+// country and without the lines that have no fields.
+func addressTemplate(c *Country) [][]addressSegment {
+	var template [][]addressSegment
+	for _, segments := range parseAddressFormat(c.addressFormat()) {
+		var line []addressSegment
+		for _, s := range segments {
+			if s.field != AddressFieldCountry {
+				line = append(line, s)
+			}
+		}
+		if hasField(line) {
+			template = append(template, line)
+		}
+	}
+	return template
+}
+
+type addressParser struct {
+	country  *Country
+	template [][]addressSegment
+	lines    []string
+	address  Address
+	regions  map[string]string
+}
+
+// streetLine returns the index of the template line with the street.
+func (p *addressParser) streetLine() int {
+	for i, line := range p.template {
+		for _, s := range line {
+			if s.field == AddressFieldStreet {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// find returns the template line with the field f and the position of the
+// field among the fields of the line.
+func (p *addressParser) find(f AddressField) (int, int) {
+	for i, line := range p.template {
+		position := 0
+		for _, s := range line {
+			if s.field == "" {
+				continue
+			}
+			if s.field == f {
+				return i, position
+			}
+			position++
+		}
+	}
+	return -1, -1
+}
+
+// textLine returns the index of the text line matching the template line i.
+// The template lines before the street are matched from the top, the ones after
+// the street from the bottom; the street line matches the first line after the
+// top ones.
+func (p *addressParser) textLine(i int) int {
+	street := p.streetLine()
+	if i <= street || street < 0 {
+		return i
+	}
+	return len(p.lines) - (len(p.template) - i)
+}
+
+// remove removes the field f from the template line i. If the line is left
+// without fields it is removed.
+func (p *addressParser) remove(i int, f AddressField) {
+	var line []addressSegment
+	for _, s := range p.template[i] {
+		if s.field != f {
+			line = append(line, s)
+		}
+	}
+	if hasField(line) {
+		p.template[i] = line
+		return
+	}
+	p.template = append(p.template[:i], p.template[i+1:]...)
+}
+
+func (p *addressParser) removeLine(j int) {
+	p.lines = append(p.lines[:j], p.lines[j+1:]...)
+}
+
+func (p *addressParser) parsePostalCode() {
+	i, position := p.find(AddressFieldPostalCode)
+	if i < 0 {
+		return
+	}
+	j := p.textLine(i)
+	if j < 0 || j >= len(p.lines) {
+		p.remove(i, AddressFieldPostalCode)
+		return
+	}
+	r := p.country.postalCodeRegexp()
+	if r.err != nil {
+		p.remove(i, AddressFieldPostalCode)
+		return
+	}
+	line := p.lines[j]
+	matches := r.search.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		p.remove(i, AddressFieldPostalCode)
+		return
+	}
+	m := matches[len(matches)-1]
+	if position == 0 {
+		m = matches[0]
+	}
+	start, end := m[2], m[3]
+	postalCode := line[start:end]
+	if normalized, err := p.country.NormalizePostalCode(postalCode); err == nil {
+		postalCode = normalized
+	}
+	p.address.PostalCode = postalCode
+	// Drop the characters bound to the postal code, like "〒".
+	before := strings.TrimRightFunc(line[:start], unicode.IsSpace)
+	for _, s := range p.template[i] {
+		if s.field == "" && strings.TrimFunc(s.literal, isAddressSeparator) != "" {
+			before = strings.TrimSuffix(before, strings.TrimSpace(s.literal))
+		}
+	}
+	p.lines[j] = trimAddressText(before + " " + line[end:])
+
+	p.remove(i, AddressFieldPostalCode)
+	if p.lines[j] == "" {
+		p.removeLine(j)
+	}
+}
+
+func (p *addressParser) parseRegion() {
+	i, position := p.find(AddressFieldRegion)
+	if i < 0 {
+		return
+	}
+	j := p.textLine(i)
+	if j < 0 || j >= len(p.lines) || len(p.country.Subdivisions) == 0 {
+		p.remove(i, AddressFieldRegion)
+		return
+	}
+	fields := 0
+	for _, s := range p.template[i] {
+		if s.field != "" {
+			fields++
+		}
+	}
+	line := p.lines[j]
+	if fields == 1 || len(p.lines) > len(p.template) {
+		// The region is on its own line, that is optional, or has been
+		// separated from the other fields by a comma.
+		if code := p.region(line); code != "" && len(p.lines) >= len(p.template) {
+			p.address.Region = code
+			p.removeLine(j)
+			p.remove(i, AddressFieldRegion)
+			return
+		}
+		if fields == 1 {
+			p.remove(i, AddressFieldRegion)
+			return
+		}
+	}
+	if code, rest := p.splitRegion(line, position == 0); code != "" {
+		p.address.Region = code
+		p.lines[j] = rest
+	}
+	p.remove(i, AddressFieldRegion)
+}
+
+// splitRegion looks for a region at the beginning (if first is true) or at the
+// end of line and returns its code and the rest of the line. The longest region
+// leaving a non empty rest wins.
+func (p *addressParser) splitRegion(line string, first bool) (string, string) {
+	words := strings.Fields(line)
+	for n := len(words) - 1; n > 0; n-- {
+		candidate, rest := strings.Join(words[len(words)-n:], " "), strings.Join(words[:len(words)-n], " ")
+		if first {
+			candidate, rest = strings.Join(words[:n], " "), strings.Join(words[n:], " ")
+		}
+		if code := p.region(candidate); code != "" {
+			return code, trimAddressText(rest)
+		}
+	}
+	if !first || len(words) == 0 || !isCJK(words[0]) {
+		return "", line
+	}
+	// Chinese and Japanese addresses do not separate the words.
+	runes := []rune(words[0])
+	for n := len(runes) - 1; n > 0; n-- {
+		if code := p.region(string(runes[:n])); code != "" {
+			return code, trimAddressText(string(runes[n:]) + " " + strings.Join(words[1:], " "))
+		}
+	}
+	return "", line
+}
+
+// region returns the code of the subdivision whose code or name is name. If no
+// subdivision matches returns an empty string.
+func (p *addressParser) region(name string) string {
+	if s := p.country.addressRegion(name); s.Code != "" {
+		return s.Code
+	}
+	if p.regions == nil {
+		p.regions = make(map[string]string)
+		for _, code := range sortedSubdivisionCodes(p.country.Subdivisions) {
+			s := p.country.Subdivisions[code]
+			names := append([]string{s.Name}, s.UnofficialNames...)
+			for _, locale := range sortedKeys(s.Translations) {
+				names = append(names, s.Translations[locale])
+			}
+			for _, n := range names {
+				if key := normalizeName(n); key != "" && p.regions[key] == "" {
+					p.regions[key] = code
+				}
+			}
+		}
+	}
+	return p.regions[normalizeName(name)]
+}
+
+// parseLines assigns the remaining text lines to the remaining template lines.
+func (p *addressParser) parseLines() (Address, error) {
+	street := p.streetLine()
+	if street < 0 || len(p.lines) < len(p.template) {
+		return Address{}, fmt.Errorf("%w: expected at least %d lines for country %s", ErrInvalidAddress, len(p.template), p.country.Alpha2)
+	}
+	for i, line := range p.template {
+		j := p.textLine(i)
+		if i != street {
+			p.set(line, p.lines[j])
+			continue
+		}
+		middle := p.lines[j : len(p.lines)-(len(p.template)-i-1)]
+		if len(line) == 1 {
+			p.address.StreetLines = append(p.address.StreetLines, middle...)
+			continue
+		}
+		p.set(line, middle[0])
+		p.address.StreetLines = append(p.address.StreetLines, middle[1:]...)
+	}
+	return p.address, nil
+}
+
+// set splits text among the fields of the template line.
+func (p *addressParser) set(line []addressSegment, text string) {
+	var fields []AddressField
+	for _, s := range line {
+		if s.field != "" {
+			fields = append(fields, s.field)
+		}
+	}
+	values := splitAddressFields(text, len(fields))
+	for k, f := range fields {
+		v := values[k]
+		switch f {
+		case AddressFieldRecipient:
+			p.address.Recipient = v
+		case AddressFieldOrganization:
+			p.address.Organization = v
+		case AddressFieldStreet:
+			if v != "" {
+				p.address.StreetLines = append(p.address.StreetLines, v)
+			}
+		case AddressFieldDependentLocality:
+			p.address.DependentLocality = v
+		case AddressFieldCity:
+			p.address.City = v
+		case AddressFieldSortingCode:
+			p.address.SortingCode = v
+		}
+	}
+}
+
+// japaneseCitySuffixes are the characters ending the name of Japanese
+// municipalities: city, ward, town and village.
+var japaneseCitySuffixes = []string{"市", "区", "町", "村"}
+
+// splitAddressFields splits text in n values: a word for each of the first n-1
+// values and the rest for the last one. Chinese and Japanese text without
+// spaces is split after the municipality name.
+func splitAddressFields(text string, n int) []string {
+	values := make([]string, n)
+	if n == 1 {
+		values[0] = text
+		return values
+	}
+	words := strings.Fields(text)
+	if len(words) == 1 && isCJK(text) {
+		for _, suffix := range japaneseCitySuffixes {
+			if k := strings.Index(text, suffix); k > 0 && k+len(suffix) < len(text) {
+				words = []string{text[:k+len(suffix)], text[k+len(suffix):]}
+				break
+			}
+		}
+	}
+	if len(words) < n {
+		values[n-1] = text
+		return values
+	}
+	copy(values, words[:n-1])
+	values[n-1] = strings.Join(words[n-1:], " ")
+	return values
+}
+
+// isCJK returns true if s has Chinese or Japanese characters.
+func isCJK(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimAddressText collapses the spaces of s and trims the separators at its
+// ends.
+func trimAddressText(s string) string {
+	return strings.TrimFunc(strings.Join(strings.Fields(s), " "), isAddressSeparator)
+}
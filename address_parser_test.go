@@ -0,0 +1,77 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		country string
+		text    string
+		want    countries.Address
+	}{
+		{"US", "John Smith\n1084 Nuzum Court\nBuffalo NY 14214\nUnited States of America", countries.Address{Recipient: "John Smith", StreetLines: []string{"1084 Nuzum Court"}, City: "Buffalo", Region: "NY", PostalCode: "14214", CountryCode: "US"}},
+		{"US", "John Smith, 1084 Nuzum Court, Apt 2, Buffalo, New York 14214-1234, USA", countries.Address{Recipient: "John Smith", StreetLines: []string{"1084 Nuzum Court", "Apt 2"}, City: "Buffalo", Region: "NY", PostalCode: "14214-1234", CountryCode: "US"}},
+		{"GB", "Jane Doe\n10 Downing Street\nLondon\nSW1A2AA\nUnited Kingdom", countries.Address{Recipient: "Jane Doe", StreetLines: []string{"10 Downing Street"}, City: "London", PostalCode: "SW1A 2AA", CountryCode: "GB"}},
+		{"GB", "Jane Doe\n1 High Street\nCanterbury\nKent\nCT1 2AA", countries.Address{Recipient: "Jane Doe", StreetLines: []string{"1 High Street"}, City: "Canterbury", Region: "KEN", PostalCode: "CT1 2AA", CountryCode: "GB"}},
+		{"DE", "Max Mustermann\nMusterstraße 12\n10115 Berlin\nDeutschland", countries.Address{Recipient: "Max Mustermann", StreetLines: []string{"Musterstraße 12"}, City: "Berlin", PostalCode: "10115", CountryCode: "DE"}},
+		{"IT", "Enrico Pilotto\nvia Garibaldi 15\n97011 Acate RG\nItalia", countries.Address{Recipient: "Enrico Pilotto", StreetLines: []string{"via Garibaldi 15"}, City: "Acate", Region: "RG", PostalCode: "97011", CountryCode: "IT"}},
+		{"IT", "Mario Rossi, Via del Corso 1, 00186 Roma", countries.Address{Recipient: "Mario Rossi", StreetLines: []string{"Via del Corso 1"}, City: "Roma", PostalCode: "00186", CountryCode: "IT"}},
+		{"FR", "Jean Dupont\n12 rue de Rivoli\n75001 Paris\nFrance", countries.Address{Recipient: "Jean Dupont", StreetLines: []string{"12 rue de Rivoli"}, City: "Paris", PostalCode: "75001", CountryCode: "FR"}},
+		{"JP", "〒100-0001\n東京都千代田区千代田1-1\n山田太郎\n日本", countries.Address{Recipient: "山田太郎", StreetLines: []string{"千代田1-1"}, City: "千代田区", Region: "13", PostalCode: "100-0001", CountryCode: "JP"}},
+		{"JP", "〒100-0001\nTokyo Chiyoda-ku 1-1 Chiyoda\nTaro Yamada", countries.Address{Recipient: "Taro Yamada", StreetLines: []string{"1-1 Chiyoda"}, City: "Chiyoda-ku", Region: "13", PostalCode: "100-0001", CountryCode: "JP"}},
+		{"", "Max Mustermann\nMusterstraße 12\n10115 Berlin\nGermany", countries.Address{Recipient: "Max Mustermann", StreetLines: []string{"Musterstraße 12"}, City: "Berlin", PostalCode: "10115", CountryCode: "DE"}},
+	}
+	for _, tt := range tests {
+		a, err := countries.ParseAddress(tt.text, countries.Get(tt.country))
+		assert.NoError(t, err, tt.text)
+		assert.Equal(t, tt.want, a, tt.text)
+	}
+
+	_, err := countries.ParseAddress(" \n ", countries.Get("US"))
+	assert.ErrorIs(t, err, countries.ErrInvalidAddress)
+	_, err = countries.ParseAddress("John Smith\nAtlantis", nil)
+	assert.ErrorIs(t, err, countries.ErrInvalidAddress)
+	_, err = countries.ParseAddress("John Smith\nBuffalo NY 14214", countries.Get("US"))
+	assert.ErrorIs(t, err, countries.ErrInvalidAddress)
+}
+
+func TestParseAddressRoundTrip(t *testing.T) {
+	for _, a := range []countries.Address{
+		{Recipient: "John Smith", StreetLines: []string{"1084 Nuzum Court"}, City: "Buffalo", Region: "NY", PostalCode: "14214", CountryCode: "US"},
+		{Recipient: "Jane Doe", StreetLines: []string{"1 High Street"}, City: "Canterbury", Region: "KEN", PostalCode: "CT1 2AA", CountryCode: "GB"},
+		{Recipient: "Max Mustermann", StreetLines: []string{"Musterstraße 12"}, City: "Berlin", PostalCode: "10115", CountryCode: "DE"},
+		{Recipient: "Enrico Pilotto", StreetLines: []string{"via Garibaldi 15"}, City: "Acate", Region: "RG", PostalCode: "97011", CountryCode: "IT"},
+		{Recipient: "Jean Dupont", StreetLines: []string{"12 rue de Rivoli"}, City: "Paris", PostalCode: "75001", CountryCode: "FR"},
+	} {
+		c := countries.Get(a.CountryCode)
+		for _, opts := range []countries.AddressFormatOptions{{}, {SingleLine: true}, {SenderCountry: c.Alpha2}} {
+			text := c.FormatAddressWithOptions(a, opts)
+			parsed, err := countries.ParseAddress(text, c)
+			assert.NoError(t, err, text)
+			assert.Equal(t, a, parsed, text)
+		}
+	}
+}
+
+func ExampleParseAddress() {
+	a, err := countries.ParseAddress("John Smith, 1084 Nuzum Court, Buffalo NY 14214, USA", countries.Get("US"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(a.Recipient)
+	fmt.Println(a.StreetLines)
+	fmt.Println(a.City)
+	fmt.Println(a.Region)
+	fmt.Println(a.PostalCode)
+	// Output:
+	// John Smith
+	// [1084 Nuzum Court]
+	// Buffalo
+	// NY
+	// 14214
+}
@@ -0,0 +1,104 @@
+package countries
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+)
+
+// addressRegionValue returns the subdivision code of the address region if the
+// country address format uses {{region_short}}, otherwise its name.
+func (c *Country) addressRegionValue(a Address) string {
+	name, code := c.addressRegionNames(a)
+	for _, segments := range parseAddressFormat(c.addressFormat()) {
+		for _, s := range segments {
+			if s.field == AddressFieldRegion && s.short {
+				return code
+			}
+		}
+	}
+	return name
+}
+
+// vCardEscaper escapes the text of a vCard property value (RFC 6350).
+var vCardEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `;`, `\;`, "\n", `\n`)
+
+// vCardParamEscaper escapes the text of a vCard parameter value (RFC 6868).
+var vCardParamEscaper = strings.NewReplacer(`^`, `^^`, "\n", `^n`, `"`, `^'`)
+
+// AddressVCard returns the address as a vCard 4.0 ADR property, with the
+// formatted address in the LABEL parameter. The street lines are the
+// components of the street address, the region is the one used by the country
+// address format and the country is the country ISOShortName. The recipient and
+// the organization are not part of the property.
+func (c *Country) AddressVCard(a Address) string {
+	street := addressStreet(a)
+	for i := range street {
+		street[i] = vCardEscaper.Replace(street[i])
+	}
+	components := []string{
+		"", // Post office box
+		"", // Extended address
+		strings.Join(street, ","),
+		vCardEscaper.Replace(strings.TrimSpace(a.City)),
+		vCardEscaper.Replace(c.addressRegionValue(a)),
+		vCardEscaper.Replace(strings.TrimSpace(a.PostalCode)),
+		vCardEscaper.Replace(c.ISOShortName),
+	}
+	label := a
+	label.Recipient, label.Organization = "", ""
+	return `ADR;LABEL="` + vCardParamEscaper.Replace(c.FormatAddressWithOptions(label, AddressFormatOptions{})) + `":` + strings.Join(components, ";")
+}
+
+// postalAddressJSONLD is a schema.org PostalAddress.
+type postalAddressJSONLD struct {
+	Context         string `json:"@context"`
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+// AddressJSONLD returns the address as a schema.org PostalAddress in JSON-LD.
+// The street lines are joined with a comma, the region is the one used by the
+// country address format and the country is the country alpha2 code. The
+// recipient and the organization are not part of the PostalAddress.
+func (c *Country) AddressJSONLD(a Address) string {
+	buf, _ := json.Marshal(postalAddressJSONLD{
+		Context:         "https://schema.org",
+		Type:            "PostalAddress",
+		StreetAddress:   strings.Join(addressStreet(a), ", "),
+		AddressLocality: strings.TrimSpace(a.City),
+		AddressRegion:   c.addressRegionValue(a),
+		PostalCode:      strings.TrimSpace(a.PostalCode),
+		AddressCountry:  c.Alpha2,
+	})
+	return string(buf)
+}
+
+// addressItemProps are the schema.org PostalAddress properties of the address
+// fields.
+var addressItemProps = map[AddressField]string{
+	AddressFieldStreet:     "streetAddress",
+	AddressFieldCity:       "addressLocality",
+	AddressFieldRegion:     "addressRegion",
+	AddressFieldPostalCode: "postalCode",
+	AddressFieldCountry:    "addressCountry",
+}
+
+// AddressHTML returns the address as HTML with schema.org PostalAddress
+// microdata: a div with a line for each line of the country address format and
+// a <span itemprop> for each field. The recipient and the organization are
+// plain text since they are not PostalAddress properties.
+func (c *Country) AddressHTML(a Address) string {
+	lines := c.addressLines(a, AddressFormatOptions{}, func(f AddressField, value string) string {
+		value = strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")
+		if prop, found := addressItemProps[f]; found {
+			return `<span itemprop="` + prop + `">` + value + `</span>`
+		}
+		return value
+	}, html.EscapeString)
+	return "<div itemscope itemtype=\"https://schema.org/PostalAddress\">\n" + strings.Join(lines, "<br>\n") + "\n</div>"
+}
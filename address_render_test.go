@@ -0,0 +1,84 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressVCard(t *testing.T) {
+	us := countries.Get("US")
+	a := countries.Address{
+		Recipient:   "John Smith",
+		StreetLines: []string{"1084 Nuzum Court", "Apt 2; rear"},
+		City:        "Buffalo",
+		Region:      "New York",
+		PostalCode:  "14214",
+		CountryCode: "US",
+	}
+	assert.Equal(t, `ADR;LABEL="1084 Nuzum Court^nApt 2; rear^nBuffalo NY 14214^nUnited States of America":;;1084 Nuzum Court,Apt 2\; rear;Buffalo;NY;14214;United States of America`, us.AddressVCard(a))
+
+	gb := countries.Get("GB")
+	a = countries.Address{StreetLines: []string{"1 High Street"}, City: "Canterbury", Region: "KEN", PostalCode: "CT1 2AA"}
+	assert.Equal(t, `ADR;LABEL="1 High Street^nCanterbury^nKent^nCT1 2AA^nUnited Kingdom of Great Britain and Northern Ireland":;;1 High Street;Canterbury;Kent;CT1 2AA;United Kingdom of Great Britain and Northern Ireland`, gb.AddressVCard(a))
+}
+
+func TestAddressJSONLD(t *testing.T) {
+	it := countries.Get("IT")
+	a := countries.Address{
+		Recipient:   "Enrico Pilotto",
+		StreetLines: []string{"via Garibaldi 15", "scala B"},
+		City:        "Acate",
+		Region:      "Ragusa",
+		PostalCode:  "97011",
+		CountryCode: "IT",
+	}
+	assert.JSONEq(t, `{
+		"@context": "https://schema.org",
+		"@type": "PostalAddress",
+		"streetAddress": "via Garibaldi 15, scala B",
+		"addressLocality": "Acate",
+		"addressRegion": "RG",
+		"postalCode": "97011",
+		"addressCountry": "IT"
+	}`, it.AddressJSONLD(a))
+	assert.JSONEq(t, `{"@context": "https://schema.org", "@type": "PostalAddress", "addressCountry": "IT"}`, it.AddressJSONLD(countries.Address{}))
+}
+
+func TestAddressHTML(t *testing.T) {
+	jp := countries.Get("JP")
+	a := countries.Address{
+		Recipient:   "Taro <Yamada>",
+		StreetLines: []string{"1-1 Chiyoda"},
+		City:        "Chiyoda-ku",
+		PostalCode:  "100-0001",
+	}
+	assert.Equal(t, `<div itemscope itemtype="https://schema.org/PostalAddress">
+〒<span itemprop="postalCode">100-0001</span><br>
+<span itemprop="addressLocality">Chiyoda-ku</span><span itemprop="streetAddress">1-1 Chiyoda</span><br>
+Taro &lt;Yamada&gt;<br>
+<span itemprop="addressCountry">Japan</span>
+</div>`, jp.AddressHTML(a))
+}
+
+func ExampleCountry_AddressHTML() {
+	c := countries.Get("US")
+	fmt.Println(c.AddressHTML(countries.Address{
+		Recipient:    "John Smith",
+		Organization: "ACME",
+		StreetLines:  []string{"1084 Nuzum Court", "Apt 2"},
+		City:         "Buffalo",
+		Region:       "NY",
+		PostalCode:   "14214",
+	}))
+	// Output:
+	// <div itemscope itemtype="https://schema.org/PostalAddress">
+	// John Smith<br>
+	// ACME<br>
+	// <span itemprop="streetAddress">1084 Nuzum Court<br>Apt 2</span><br>
+	// <span itemprop="addressLocality">Buffalo</span> <span itemprop="addressRegion">NY</span> <span itemprop="postalCode">14214</span><br>
+	// <span itemprop="addressCountry">United States of America</span>
+	// </div>
+}
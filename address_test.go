@@ -0,0 +1,169 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressSchema(t *testing.T) {
+	us := countries.Get("US").AddressSchema()
+	assert.Equal(t, [][]countries.AddressField{
+		{countries.AddressFieldRecipient},
+		{countries.AddressFieldOrganization},
+		{countries.AddressFieldStreet},
+		{countries.AddressFieldCity, countries.AddressFieldRegion, countries.AddressFieldPostalCode},
+		{countries.AddressFieldCountry},
+	}, us.Lines)
+	assert.True(t, us.Uses(countries.AddressFieldRegion))
+	assert.True(t, us.Required(countries.AddressFieldRegion))
+	assert.True(t, us.Required(countries.AddressFieldPostalCode))
+	assert.False(t, us.Required(countries.AddressFieldOrganization))
+	assert.False(t, us.Uses(countries.AddressFieldSortingCode))
+	assert.Equal(t, "State", us.Label(countries.AddressFieldRegion))
+	assert.Equal(t, "ZIP code", us.Label(countries.AddressFieldPostalCode))
+	assert.Equal(t, "", us.Label(countries.AddressFieldSortingCode))
+
+	jp := countries.Get("JP").AddressSchema()
+	assert.Equal(t, "Prefecture", jp.Label(countries.AddressFieldRegion))
+	assert.Equal(t, countries.AddressFieldPostalCode, jp.Fields[0].Field)
+
+	de := countries.Get("DE").AddressSchema()
+	assert.False(t, de.Uses(countries.AddressFieldRegion))
+	assert.Equal(t, "Postal code", de.Label(countries.AddressFieldPostalCode))
+
+	gb := countries.Get("GB").AddressSchema()
+	assert.Equal(t, "County", gb.Label(countries.AddressFieldRegion))
+	assert.False(t, gb.Required(countries.AddressFieldRegion))
+
+	jm := countries.Get("JM").AddressSchema()
+	assert.True(t, jm.Uses(countries.AddressFieldPostalCode))
+	assert.False(t, jm.Required(countries.AddressFieldPostalCode))
+
+	for _, c := range countries.Data.All {
+		schema := c.AddressSchema()
+		for _, f := range []countries.AddressField{countries.AddressFieldRecipient, countries.AddressFieldStreet, countries.AddressFieldCity, countries.AddressFieldCountry} {
+			assert.True(t, schema.Required(f), "%s: %s", c.Alpha2, f)
+		}
+	}
+}
+
+func TestAddressValue(t *testing.T) {
+	a := countries.Address{StreetLines: []string{"1084 Nuzum Court", "Apt 2"}, CountryCode: "US"}
+	assert.Equal(t, "1084 Nuzum Court\nApt 2", a.Value(countries.AddressFieldStreet))
+	assert.Equal(t, "US", a.Value(countries.AddressFieldCountry))
+	assert.Equal(t, "", a.Value(countries.AddressFieldCity))
+}
+
+func ExampleCountry_AddressSchema() {
+	schema := countries.Get("US").AddressSchema()
+	for _, f := range schema.Fields {
+		fmt.Println(f.Field, f.Label, f.Required)
+	}
+	// Output:
+	// recipient Name true
+	// organization Organization false
+	// street Street address true
+	// city City true
+	// region State true
+	// postalcode ZIP code true
+	// country Country true
+}
+
+func TestValidateAddress(t *testing.T) {
+	us := countries.Get("US")
+	valid := countries.Address{
+		Recipient:   "John Smith",
+		StreetLines: []string{"1084 Nuzum Court"},
+		City:        "Buffalo",
+		Region:      "NY",
+		PostalCode:  "14214",
+		CountryCode: "US",
+	}
+	assert.Empty(t, us.ValidateAddress(valid))
+
+	a := valid
+	a.Region = "New York"
+	assert.Empty(t, us.ValidateAddress(a))
+
+	errs := us.ValidateAddress(countries.Address{CountryCode: "US"})
+	fields := make([]countries.AddressField, 0)
+	for _, err := range errs {
+		assert.ErrorIs(t, err, countries.ErrAddressFieldRequired)
+		fields = append(fields, err.Field)
+	}
+	assert.Equal(t, []countries.AddressField{
+		countries.AddressFieldRecipient,
+		countries.AddressFieldStreet,
+		countries.AddressFieldCity,
+		countries.AddressFieldRegion,
+		countries.AddressFieldPostalCode,
+	}, fields)
+
+	a = valid
+	a.PostalCode = "1421"
+	errs = us.ValidateAddress(a)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.Equal(t, countries.AddressFieldPostalCode, errs[0].Field)
+		assert.ErrorIs(t, errs[0], countries.ErrPostalCodeMismatch)
+	}
+
+	a = valid
+	a.Region = "Atlantis"
+	errs = us.ValidateAddress(a)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.Equal(t, countries.AddressFieldRegion, errs[0].Field)
+		assert.ErrorIs(t, errs[0], countries.ErrUnknownRegion)
+	}
+
+	a = valid
+	a.Region = "CA"
+	errs = us.ValidateAddress(a)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.ErrorIs(t, errs[0], countries.ErrPostalCodeSubdivisionMismatch)
+	}
+
+	// The country code of a domestic address can be omitted
+	a = valid
+	a.CountryCode = ""
+	assert.Empty(t, us.ValidateAddress(a))
+
+	a = valid
+	a.CountryCode = "IT"
+	errs = us.ValidateAddress(a)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.ErrorIs(t, errs[0], countries.ErrAddressCountryMismatch)
+	}
+
+	jm := countries.Get("JM")
+	errs = jm.ValidateAddress(countries.Address{
+		Recipient:   "John Smith",
+		StreetLines: []string{"1 Main Street"},
+		City:        "Kingston",
+		PostalCode:  "12345",
+		CountryCode: "JM",
+	})
+	if assert.Equal(t, 1, len(errs)) {
+		assert.ErrorIs(t, errs[0], countries.ErrNoPostalCodeSystem)
+	}
+}
+
+func ExampleCountry_ValidateAddress() {
+	c := countries.Get("IT")
+	errs := c.ValidateAddress(countries.Address{
+		Recipient:   "Enrico Pilotto",
+		StreetLines: []string{"via Garibaldi 15"},
+		City:        "Acate",
+		Region:      "XX",
+		PostalCode:  "970111",
+		CountryCode: "IT",
+	})
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	// Output:
+	// region: unknown region: "XX"
+	// postalcode: postal code "970111" for country IT: postal code does not match the country format
+}
@@ -0,0 +1,89 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrAddressFieldRequired is returned when a required address field is
+	// empty.
+	ErrAddressFieldRequired = errors.New("field is required")
+	// ErrUnknownRegion is returned when an address region is neither the code
+	// nor the name of a country subdivision.
+	ErrUnknownRegion = errors.New("unknown region")
+	// ErrAddressCountryMismatch is returned when the address country code is
+	// not the code of the country validating the address.
+	ErrAddressCountryMismatch = errors.New("address country does not match")
+)
+
+// FieldError is an error about a field of an address.
+type FieldError struct {
+	Field AddressField
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateAddress checks the address against the country rules and returns an
+// error for each invalid field, or an empty slice if the address is valid. It
+// reports missing required fields (see AddressSchema), postal codes that do not
+// match the country format or that are given for a country without postal
+// codes, postal codes that do not belong to the region, regions that are not a
+// known subdivision code or name, and country codes other than the country
+// one. An empty country code is the country one, since the country is known.
+func (c *Country) ValidateAddress(a Address) []FieldError {
+	result := make([]FieldError, 0)
+	schema := c.AddressSchema()
+	for _, field := range schema.Fields {
+		if field.Field == AddressFieldCountry {
+			continue
+		}
+		if field.Required && strings.TrimSpace(a.Value(field.Field)) == "" {
+			result = append(result, FieldError{Field: field.Field, Err: ErrAddressFieldRequired})
+		}
+	}
+
+	if a.CountryCode != "" && normalizeCode(a.CountryCode) != c.Alpha2 {
+		result = append(result, FieldError{Field: AddressFieldCountry, Err: fmt.Errorf("%w: %s is not %s", ErrAddressCountryMismatch, a.CountryCode, c.Alpha2)})
+	}
+
+	region := strings.TrimSpace(a.Region)
+	regionKnown := false
+	if region != "" && len(c.Subdivisions) > 0 {
+		if c.addressRegion(region).Code == "" {
+			result = append(result, FieldError{Field: AddressFieldRegion, Err: fmt.Errorf("%w: %q", ErrUnknownRegion, region)})
+		} else {
+			regionKnown = true
+		}
+	}
+
+	postalCode := strings.TrimSpace(a.PostalCode)
+	if postalCode != "" {
+		if err := c.ValidatePostalCode(postalCode); err != nil {
+			result = append(result, FieldError{Field: AddressFieldPostalCode, Err: err})
+		} else if regionKnown {
+			if err := c.ValidatePostalCodeSubdivision(postalCode, c.addressRegion(region).Code); err != nil {
+				result = append(result, FieldError{Field: AddressFieldPostalCode, Err: err})
+			}
+		}
+	}
+	return result
+}
+
+// addressRegion returns the subdivision identified by region, a subdivision
+// code (case insensitive) or name. If the region is not found returns a zero
+// value Subdivision.
+func (c *Country) addressRegion(region string) Subdivision {
+	if s := c.Subdivision(strings.ToUpper(region)); s.Code != "" {
+		return s
+	}
+	return c.SubdivisionByName(region)
+}
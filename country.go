@@ -6,12 +6,11 @@ import (
 	"embed"
 	"log"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 )
 
-//go:embed data/**/*
+//go:embed data
 var content embed.FS
 
 type CountryData struct {
@@ -19,6 +18,26 @@ type CountryData struct {
 	Alpha2     []string
 	Regions    []string
 	Subregions []string
+	Currencies []Currency
+
+	byAlpha2   codeIndex
+	byAlpha3   codeIndex
+	byNumeric  codeIndex
+	byIOC      codeIndex
+	byGEC      codeIndex
+	byUnLocode codeIndex
+	byName     nameIndex
+
+	byCallingCode   codeIndex
+	byPhonePrefix   codeIndex
+	phonePrefixes   map[string][]string
+	phoneNDCLengths map[string]map[string]int
+
+	byCurrencyCode currencyIndex
+	byCurrency     map[string][]*Country
+
+	postalCodes            map[string]postalCodeRegexp
+	postalCodeSubdivisions map[string]postalCodeTable
 }
 
 func loadCountryData(dataPath string) (*CountryData, error) {
@@ -57,15 +76,44 @@ func loadCountryData(dataPath string) (*CountryData, error) {
 		return nil, err
 	}
 
+	// Load postal code prefixes Data from embedded Data file
+	allPostalCodePrefixes := make(map[string]postalCodePrefixes)
+	err = loadPostalCodePrefixes(filepath.Join(dataPath, "postal_codes.yaml"), allPostalCodePrefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load currencies Data from embedded Data files
+	allCurrencies := make(map[string]Currency)
+	err = loadCurrencies(filepath.Join(dataPath, "currencies"), allCurrencies)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load phone prefixes Data from embedded Data file
+	allPhonePrefixes := make(map[string][]string)
+	err = loadPhonePrefixes(filepath.Join(dataPath, "phone_prefixes.yaml"), allPhonePrefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load phone national destination code lengths Data from embedded Data file
+	allPhoneNDCLengths := make(map[string]map[string]int)
+	err = loadPhoneNDCLengths(filepath.Join(dataPath, "phone_ndc_lengths.yaml"), allPhoneNDCLengths)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build and sort All slice
 	var all []Country
 	for countryAlpha2, c := range allCountries {
 		c.Capital = allCapitals[countryAlpha2]
 		c.Subdivisions = make(map[string]Subdivision)
 		for code, subdivision := range allSubdivisions[countryAlpha2] {
-			if subdivision.Type == "metropolitan_city" && subdivision.Translations["en"] == c.Capital {
+			if subdivision.Type == SubdivisionTypeMetropolitanCity && subdivision.Translations["en"] == c.Capital {
 				subdivision.Capital = true
 			}
+			subdivision.CountryAlpha2 = countryAlpha2
 			c.Subdivisions[code] = *subdivision
 		}
 		c.Timezones = allTimezones[countryAlpha2]
@@ -83,6 +131,7 @@ func loadCountryData(dataPath string) (*CountryData, error) {
 	alpha2 := alpha2(all)
 	regions := regions(all)
 	subregions := subregions(all)
+	currencies, byCurrencyCode := buildCurrencies(allCurrencies)
 
 	// Return the structured Data
 	return &CountryData{
@@ -90,6 +139,25 @@ func loadCountryData(dataPath string) (*CountryData, error) {
 		Alpha2:     alpha2,
 		Regions:    regions,
 		Subregions: subregions,
+		Currencies: currencies,
+		byAlpha2:   buildCodeIndex(all, func(c *Country) string { return c.Alpha2 }),
+		byAlpha3:   buildCodeIndex(all, func(c *Country) string { return c.Alpha3 }),
+		byNumeric:  buildCodeIndex(all, func(c *Country) string { return c.Number }),
+		byIOC:      buildCodeIndex(all, func(c *Country) string { return c.IOC }),
+		byGEC:      buildCodeIndex(all, func(c *Country) string { return c.GEC }),
+		byUnLocode: buildCodeIndex(all, func(c *Country) string { return c.UnLocode }),
+		byName:     buildNameIndex(all),
+
+		byCallingCode:   buildCallingCodeIndex(all),
+		byPhonePrefix:   buildPhonePrefixIndex(all, allPhonePrefixes),
+		phonePrefixes:   allPhonePrefixes,
+		phoneNDCLengths: allPhoneNDCLengths,
+
+		byCurrencyCode: byCurrencyCode,
+		byCurrency:     buildCurrencyCountriesIndex(all),
+
+		postalCodes:            buildPostalCodeIndex(all),
+		postalCodeSubdivisions: buildPostalCodeTables(allPostalCodePrefixes),
 	}, nil
 }
 
@@ -117,12 +185,13 @@ type Geo struct {
 	Bounds       Bounds  `yaml:"bounds"`
 }
 
-// VatRates store the VAT (Value Added Tax) rates of a country.
+// VatRates store the VAT (Value Added Tax) rates of a country, as
+// percentages. See Country.VAT to tax an amount of money.
 type VatRates struct {
-	Standard     int   `yaml:"standard"`
-	Reduced      []int `yaml:"reduced"`
-	SuperReduced int   `yaml:"super_reduced"`
-	Parking      int   `yaml:"parking"`
+	Standard     float64   `yaml:"standard"`
+	Reduced      []float64 `yaml:"reduced"`
+	SuperReduced float64   `yaml:"super_reduced"`
+	Parking      float64   `yaml:"parking"`
 }
 
 // Country store all information about a country.
@@ -130,6 +199,7 @@ type Country struct {
 	AddressFormat                  string                 `yaml:"address_format"`
 	Alpha2                         string                 `yaml:"alpha2"`
 	Alpha3                         string                 `yaml:"alpha3"`
+	AltCurrency                    string                 `yaml:"alt_currency"`
 	Capital                        string                 `yaml:"capital"`
 	Continent                      string                 `yaml:"continent"`
 	CountryCode                    string                 `yaml:"country_code"`
@@ -139,6 +209,7 @@ type Country struct {
 	G7Member                       bool                   `yaml:"g7_member"`
 	G20Member                      bool                   `yaml:"g20_member"`
 	ESMMember                      bool                   `yaml:"esm_member"`
+	EUVATMembership                *bool                  `yaml:"euvat_member"`
 	GEC                            string                 `yaml:"gec"`
 	Geo                            Geo                    `yaml:"geo"`
 	InternationalPrefix            string                 `yaml:"international_prefix"`
@@ -147,11 +218,13 @@ type Country struct {
 	ISOShortName                   string                 `yaml:"iso_short_name"`
 	LanguagesOfficial              []string               `yaml:"languages_official"`
 	LanguagesSpoken                []string               `yaml:"languages_spoken"`
+	NANPPrefix                     string                 `yaml:"nanp_prefix"`
 	NationalDestinationCodeLengths []int                  `yaml:"national_destination_code_lengths"`
 	NationalNumberLengths          []int                  `yaml:"national_number_lengths"`
 	NationalPrefix                 string                 `yaml:"national_prefix"`
 	Nationality                    string                 `yaml:"nationality"`
 	Number                         string                 `yaml:"number"`
+	PostalCode                     bool                   `yaml:"postal_code"`
 	PostalCodeFormat               string                 `yaml:"postal_code_format"`
 	Region                         string                 `yaml:"region"`
 	StartOfWeek                    string                 `yaml:"start_of_week"`
@@ -168,12 +241,16 @@ type Country struct {
 // Subdivision store information about a subdivision like a region or a province
 // or a state or a metropolitan city of a country.
 type Subdivision struct {
-	Name         string            `yaml:"name"`
-	Code         string            `yaml:"code"`
-	Type         string            `yaml:"type"`
-	Capital      bool              `yaml:"capital"`
-	Geo          Geo               `yaml:"geo"`
-	Translations map[string]string `yaml:"translations"`
+	Name            string            `yaml:"name"`
+	Code            string            `yaml:"code"`
+	CountryAlpha2   string            `yaml:"-"`
+	Parent          string            `yaml:"parent"`
+	Type            SubdivisionType   `yaml:"type"`
+	Capital         bool              `yaml:"capital"`
+	Geo             Geo               `yaml:"geo"`
+	Translations    map[string]string `yaml:"translations"`
+	UnofficialNames []string          `yaml:"-"`
+	Comments        string            `yaml:"comments"`
 }
 
 // InEU returns all countries that are members of the European Union.
@@ -215,17 +292,81 @@ func (c *Country) Subdivision(code string) Subdivision {
 	return c.Subdivisions[code]
 }
 
-// SubdivisionByName returns the country's subdivision with name name. If the
-// name is not valid or not found returns a zero value Subdivision.
+// SubdivisionByName returns the country's subdivision with name name. The name
+// is compared with the subdivision Name, then with its UnofficialNames and
+// finally with its Translations. If the name is not valid or not found returns
+// a zero value Subdivision.
 func (c *Country) SubdivisionByName(name string) Subdivision {
-	for _, s := range c.Subdivisions {
-		if s.Name == name {
+	if name == "" {
+		return Subdivision{}
+	}
+	codes := sortedSubdivisionCodes(c.Subdivisions)
+	for _, code := range codes {
+		if s := c.Subdivisions[code]; s.Name == name {
 			return s
 		}
 	}
+	for _, code := range codes {
+		s := c.Subdivisions[code]
+		for _, unofficialName := range s.UnofficialNames {
+			if unofficialName == name {
+				return s
+			}
+		}
+	}
+	for _, code := range codes {
+		s := c.Subdivisions[code]
+		for _, translation := range s.Translations {
+			if translation == name {
+				return s
+			}
+		}
+	}
 	return Subdivision{}
 }
 
+// sortedSubdivisionCodes returns the codes of subdivisions sorted
+// alphabetically.
+func sortedSubdivisionCodes(subdivisions map[string]Subdivision) []string {
+	codes := make([]string, 0, len(subdivisions))
+	for code := range subdivisions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// EUVATMember returns true if the country is part of the European Union VAT
+// area. Usually this is the same of EUMember, but some territories of EU
+// members are outside the VAT area (like the French overseas departments) and
+// some non members are inside it (like Monaco): for them EUVATMembership is
+// set.
+func (c *Country) EUVATMember() bool {
+	if c.EUVATMembership != nil {
+		return *c.EUVATMembership
+	}
+	return c.EUMember
+}
+
+// AltCurrencies returns the ISO 4217 codes of the currencies used in the
+// country besides CurrencyCode.
+func (c *Country) AltCurrencies() []string {
+	if c.AltCurrency == "" {
+		return []string{}
+	}
+	return []string{c.AltCurrency}
+}
+
+// NANPPrefixes returns the dialing prefixes (country code plus area code) of
+// the country if it is part of the North American Numbering Plan but it is not
+// identified by its country code alone, like "1876" for Jamaica.
+func (c *Country) NANPPrefixes() []string {
+	if c.NANPPrefix == "" {
+		return []string{}
+	}
+	return []string{c.NANPPrefix}
+}
+
 // HasPostalCode determines whether the country has postal codes. It returns
 // true if the country has postal codes, and false if it does not.
 func (c *Country) HasPostalCode() bool {
@@ -233,39 +374,27 @@ func (c *Country) HasPostalCode() bool {
 }
 
 // MatchPostalCode returns true if postalCode has a valid format for the
-// country. If the country does not have a postal code, returns false.
+// country. If the country does not have a postal code, returns false. See
+// ValidatePostalCode to know why a postal code is not valid.
 func (c *Country) MatchPostalCode(postalCode string) bool {
-	if !c.HasPostalCode() {
-		return false
-	}
-	r := regexp.MustCompile(c.PostalCodeFormat)
-	return r.Match([]byte(postalCode))
+	return c.ValidatePostalCode(postalCode) == nil
 }
 
 // FormatAddress returns the formatted address based on country.AddressFormat
-// template.
+// template. If the country has no AddressFormat returns an empty string. See
+// FormatAddressWithOptions to format an Address, also in countries without an
+// AddressFormat.
 func (c *Country) FormatAddress(recipient, street, postalCode, city, region string) string {
-	subdivision := c.Subdivision(region)
-	if subdivision.Name == "" {
-		subdivision = c.SubdivisionByName(region)
-	}
-	regionName := subdivision.Name
-	regionShortName := subdivision.Code
-	if regionName == "" {
-		regionName = region
-	}
-	if regionShortName == "" {
-		regionShortName = region
+	if c.AddressFormat == "" {
+		return ""
 	}
-	a := c.AddressFormat
-	a = strings.ReplaceAll(a, "{{recipient}}", recipient)
-	a = strings.ReplaceAll(a, "{{street}}", street)
-	a = strings.ReplaceAll(a, "{{postalcode}}", postalCode)
-	a = strings.ReplaceAll(a, "{{city}}", city)
-	a = strings.ReplaceAll(a, "{{region}}", regionName)
-	a = strings.ReplaceAll(a, "{{region_short}}", regionShortName)
-	a = strings.ReplaceAll(a, "{{country}}", c.ISOShortName)
-	return a
+	return c.FormatAddressWithOptions(Address{
+		Recipient:   recipient,
+		StreetLines: []string{street},
+		PostalCode:  postalCode,
+		City:        city,
+		Region:      region,
+	}, AddressFormatOptions{})
 }
 
 // GDPRCompliant returns true if the country is GDPR (General Data Protection
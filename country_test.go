@@ -2,11 +2,15 @@ package countries_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/pioz/countries"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNotExistingCountry(t *testing.T) {
@@ -45,7 +49,7 @@ func TestCountry(t *testing.T) {
 	assert.Equal(t, []string{"it"}, c.LanguagesOfficial)
 	assert.Equal(t, []string{"it"}, c.LanguagesSpoken)
 	assert.Equal(t, []int{3}, c.NationalDestinationCodeLengths)
-	assert.Equal(t, []int{9, 11}, c.NationalNumberLengths)
+	assert.Equal(t, []int{6, 7, 8, 9, 10, 11}, c.NationalNumberLengths)
 	assert.Equal(t, "None", c.NationalPrefix)
 	assert.Equal(t, "Italian", c.Nationality)
 	assert.Equal(t, "380", c.Number)
@@ -58,11 +62,79 @@ func TestCountry(t *testing.T) {
 	assert.Equal(t, "Europe/Rome", c.Timezones[0])
 	assert.Equal(t, "IT", c.UnLocode)
 	assert.Equal(t, []string{"Italy", "Italien", "Italie", "Italia", "イタリア", "Italië"}, c.UnofficialNames)
-	assert.Equal(t, 22, c.VatRates.Standard)
-	assert.Equal(t, []int{10}, c.VatRates.Reduced)
-	assert.Equal(t, 4, c.VatRates.SuperReduced)
-	assert.Equal(t, 0, c.VatRates.Parking)
+	assert.Equal(t, 22.0, c.VatRates.Standard)
+	assert.Equal(t, []float64{10}, c.VatRates.Reduced)
+	assert.Equal(t, 4.0, c.VatRates.SuperReduced)
+	assert.Equal(t, 0.0, c.VatRates.Parking)
 	assert.Equal(t, "EMEA", c.WorldRegion)
+	assert.Equal(t, "", c.AltCurrency)
+	assert.Nil(t, c.EUVATMembership)
+	assert.Equal(t, "", c.NANPPrefix)
+	assert.Equal(t, true, c.PostalCode)
+}
+
+// yamlKeys returns the yaml keys decoded by the fields of the struct type t.
+func yamlKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key != "" && key != "-" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// assertDataKeys asserts that every key of the entries of the yaml files in dir
+// is decoded by a field of the struct type t or is one of extraKeys.
+func assertDataKeys(t *testing.T, dir string, typ reflect.Type, extraKeys ...string) {
+	keys := yamlKeys(typ)
+	for _, key := range extraKeys {
+		keys[key] = true
+	}
+	files, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	for _, file := range files {
+		buf, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		assert.Nil(t, err)
+		var entries map[string]map[string]interface{}
+		assert.Nil(t, yaml.Unmarshal(buf, &entries))
+		for code, entry := range entries {
+			for key := range entry {
+				assert.True(t, keys[key], "%s: key %q of %s is not loaded by %s", file.Name(), key, code, typ.Name())
+			}
+		}
+	}
+}
+
+func TestDataKeysAreLoaded(t *testing.T) {
+	assertDataKeys(t, "data/countries", reflect.TypeOf(countries.Country{}))
+	// unofficial_names is decoded by Subdivision.UnmarshalYAML
+	assertDataKeys(t, "data/subdivisions", reflect.TypeOf(countries.Subdivision{}), "unofficial_names")
+	assertDataKeys(t, "data/currencies", reflect.TypeOf(countries.Currency{}))
+}
+
+func TestEUVATMember(t *testing.T) {
+	assert.True(t, countries.Get("IT").EUVATMember())
+	assert.True(t, countries.Get("MC").EUVATMember())
+	assert.False(t, countries.Get("GF").EUVATMember())
+	assert.False(t, countries.Get("US").EUVATMember())
+}
+
+func TestAltCurrencies(t *testing.T) {
+	assert.Equal(t, []string{"ZAR"}, countries.Get("LS").AltCurrencies())
+	assert.Equal(t, []string{}, countries.Get("IT").AltCurrencies())
+}
+
+func TestNANPPrefixes(t *testing.T) {
+	assert.Equal(t, []string{"1876"}, countries.Get("JM").NANPPrefixes())
+	assert.Equal(t, []string{}, countries.Get("US").NANPPrefixes())
+}
+
+func TestPostalCode(t *testing.T) {
+	for _, c := range countries.Data.All {
+		assert.Equal(t, c.HasPostalCode(), c.PostalCode, c.Alpha2)
+	}
 }
 
 func ExampleGet() {
@@ -210,9 +282,9 @@ func ExampleGet_readmeVATRates() {
 	fmt.Println(c.VatRates.Parking)
 	// Output:
 	// 23
-	// [9 13]
-	// 4
-	// 13
+	// [9 13.5]
+	// 4.8
+	// 13.5
 }
 
 func ExampleGet_readmeEuropeanUnionMembership() {
@@ -315,7 +387,7 @@ func TestSubdivision(t *testing.T) {
 	assert.Equal(t, 41.9027835, subdivision.Geo.Latitude)
 	assert.Equal(t, 12.4963655, subdivision.Geo.Longitude)
 	assert.Equal(t, "Rome", subdivision.Translations["en"])
-	assert.Equal(t, "metropolitan_city", subdivision.Type)
+	assert.Equal(t, countries.SubdivisionTypeMetropolitanCity, subdivision.Type)
 	assert.True(t, subdivision.Capital)
 }
 
@@ -390,6 +462,10 @@ func TestFormatAddress(t *testing.T) {
 	address = es.FormatAddress("Enrico Pilotto", "via Garibaldi 15", "97011", "Acate", "xx")
 	assert.Equal(t, "Enrico Pilotto\nvia Garibaldi 15\n97011 Acate\nxx\nSpain", address)
 
+	ad := countries.Get("AD")
+	assert.Equal(t, "", ad.AddressFormat)
+	assert.Equal(t, "", ad.FormatAddress("Enrico Pilotto", "Carrer Major 1", "AD500", "Andorra la Vella", ""))
+
 	for _, c := range countries.Data.All {
 		address := c.FormatAddress("Enrico Pilotto", "via Garibaldi 15", "97011", "Acate", "RG")
 		assert.False(t, strings.Contains(address, "{{"), fmt.Sprintf("Invalid formatted address for country %s", c.Alpha2))
@@ -0,0 +1,127 @@
+package countries
+
+import (
+	"sort"
+)
+
+// Currency is an ISO 4217 currency. MinorUnits is the number of digits after
+// the decimal separator, like 2 for EUR or 0 for JPY. Symbol is the
+// international symbol, that identifies the currency even abroad, like "US$",
+// and NarrowSymbol the symbol used where the currency is the local one, like
+// "$".
+type Currency struct {
+	Code         string            `yaml:"code"`
+	Number       string            `yaml:"number"`
+	MinorUnits   int               `yaml:"minor_units"`
+	Name         string            `yaml:"name"`
+	Symbol       string            `yaml:"symbol"`
+	NarrowSymbol string            `yaml:"narrow_symbol"`
+	Translations map[string]string `yaml:"translations"`
+}
+
+// currencyIndex maps a currency code to the currency it identifies.
+type currencyIndex map[string]*Currency
+
+// buildCurrencies returns all currencies sorted by code and an index of them
+// by alphabetic and numeric code.
+func buildCurrencies(allCurrencies map[string]Currency) ([]Currency, currencyIndex) {
+	currencies := make([]Currency, 0, len(allCurrencies))
+	for _, currency := range allCurrencies {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+	index := make(currencyIndex, 2*len(currencies))
+	for i := range currencies {
+		index[currencies[i].Code] = &currencies[i]
+		index[currencies[i].Number] = &currencies[i]
+	}
+	return currencies, index
+}
+
+// buildCurrencyCountriesIndex returns the countries using each currency, as
+// primary or alternative currency, sorted by alpha2 code.
+func buildCurrencyCountriesIndex(all []Country) map[string][]*Country {
+	index := make(map[string][]*Country)
+	for i := range all {
+		for _, code := range all[i].currencyCodes() {
+			index[code] = append(index[code], &all[i])
+		}
+	}
+	return index
+}
+
+// GetCurrency returns the currency identified by the ISO 4217 alphabetic code,
+// like "EUR", or numeric code, like "978". The alphabetic code is case
+// insensitive. If the code is not found returns nil.
+func GetCurrency(code string) *Currency {
+	code = normalizeCode(code)
+	if isDigits(code) {
+		code = normalizeNumericCode(code)
+	}
+	return Data.byCurrencyCode[code]
+}
+
+// Currency returns the currency identified by the country CurrencyCode. If the
+// currency is not known returns nil.
+func (c *Country) Currency() *Currency {
+	return GetCurrency(c.CurrencyCode)
+}
+
+// CountriesUsingCurrency returns the countries using the currency identified
+// by the ISO 4217 alphabetic or numeric code, as primary or alternative
+// currency, sorted by alpha2 code. If no country uses the currency returns an
+// empty slice.
+func CountriesUsingCurrency(code string) []*Country {
+	result := make([]*Country, 0)
+	if currency := GetCurrency(code); currency != nil {
+		result = append(result, Data.byCurrency[currency.Code]...)
+	}
+	return result
+}
+
+// CountryCurrency is a currency used in a country. Primary is true for the
+// country CurrencyCode. LegalTender is true if the currency is legal tender in
+// the country, false if it is only widely accepted.
+type CountryCurrency struct {
+	Currency    *Currency
+	Primary     bool
+	LegalTender bool
+}
+
+// altCurrencyLegalTender lists the countries where the alternative currency is
+// legal tender, like the South African rand in the Common Monetary Area.
+var altCurrencyLegalTender = map[string]bool{
+	"LS": true,
+	"NA": true,
+	"PA": true,
+}
+
+// currencyCodes returns the codes of CurrencyCode and AltCurrencies.
+func (c *Country) currencyCodes() []string {
+	codes := c.AltCurrencies()
+	if c.CurrencyCode != "" {
+		codes = append([]string{c.CurrencyCode}, codes...)
+	}
+	return codes
+}
+
+// Currencies returns the currencies used in the country: the primary currency
+// first, then the alternative ones. Unknown currencies are skipped.
+func (c *Country) Currencies() []CountryCurrency {
+	result := make([]CountryCurrency, 0)
+	for i, code := range c.currencyCodes() {
+		currency := GetCurrency(code)
+		if currency == nil {
+			continue
+		}
+		primary := i == 0 && code == c.CurrencyCode
+		result = append(result, CountryCurrency{
+			Currency:    currency,
+			Primary:     primary,
+			LegalTender: primary || altCurrencyLegalTender[c.Alpha2],
+		})
+	}
+	return result
+}
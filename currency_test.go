@@ -0,0 +1,118 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCurrency(t *testing.T) {
+	eur := countries.GetCurrency("EUR")
+	if assert.NotNil(t, eur) {
+		assert.Equal(t, "EUR", eur.Code)
+		assert.Equal(t, "978", eur.Number)
+		assert.Equal(t, 2, eur.MinorUnits)
+		assert.Equal(t, "Euro", eur.Name)
+		assert.Equal(t, "€", eur.Symbol)
+		assert.Equal(t, "€", eur.NarrowSymbol)
+		assert.Equal(t, "euro", eur.Translations["it"])
+	}
+	assert.Equal(t, eur, countries.GetCurrency("eur"))
+	assert.Equal(t, eur, countries.GetCurrency("978"))
+
+	usd := countries.GetCurrency("840")
+	if assert.NotNil(t, usd) {
+		assert.Equal(t, "USD", usd.Code)
+		assert.Equal(t, "US$", usd.Symbol)
+		assert.Equal(t, "$", usd.NarrowSymbol)
+	}
+	assert.Equal(t, "ALL", countries.GetCurrency("8").Code)
+	assert.Equal(t, 0, countries.GetCurrency("JPY").MinorUnits)
+	assert.Equal(t, 3, countries.GetCurrency("KWD").MinorUnits)
+	assert.Nil(t, countries.GetCurrency("XXX"))
+	assert.Nil(t, countries.GetCurrency(""))
+
+	for _, c := range countries.Data.All {
+		assert.NotNil(t, c.Currency(), c.Alpha2)
+	}
+	for _, currency := range countries.Data.Currencies {
+		assert.Len(t, currency.Code, 3, currency.Code)
+		assert.Len(t, currency.Number, 3, currency.Code)
+		assert.NotEmpty(t, currency.Name, currency.Code)
+		assert.NotEmpty(t, currency.Symbol, currency.Code)
+		assert.NotEmpty(t, currency.NarrowSymbol, currency.Code)
+	}
+}
+
+func TestCountriesUsingCurrency(t *testing.T) {
+	eur := countries.CountriesUsingCurrency("EUR")
+	assert.Greater(t, len(eur), 30)
+	for _, c := range eur {
+		assert.Equal(t, "EUR", c.CurrencyCode)
+	}
+	assert.Equal(t, "AD", eur[0].Alpha2)
+	assert.Equal(t, eur, countries.CountriesUsingCurrency("978"))
+
+	chf := countries.CountriesUsingCurrency("chf")
+	if assert.Len(t, chf, 2) {
+		assert.Equal(t, "CH", chf[0].Alpha2)
+		assert.Equal(t, "LI", chf[1].Alpha2)
+	}
+	assert.Empty(t, countries.CountriesUsingCurrency("XXX"))
+}
+
+func TestCountryCurrencies(t *testing.T) {
+	currencies := countries.Get("LS").Currencies()
+	if assert.Len(t, currencies, 2) {
+		assert.Equal(t, "LSL", currencies[0].Currency.Code)
+		assert.True(t, currencies[0].Primary)
+		assert.True(t, currencies[0].LegalTender)
+		assert.Equal(t, "ZAR", currencies[1].Currency.Code)
+		assert.False(t, currencies[1].Primary)
+		assert.True(t, currencies[1].LegalTender)
+	}
+
+	currencies = countries.Get("TJ").Currencies()
+	if assert.Len(t, currencies, 2) {
+		assert.Equal(t, "TJS", currencies[0].Currency.Code)
+		assert.Equal(t, "RUB", currencies[1].Currency.Code)
+		assert.False(t, currencies[1].Primary)
+		assert.False(t, currencies[1].LegalTender)
+	}
+
+	currencies = countries.Get("IT").Currencies()
+	if assert.Len(t, currencies, 1) {
+		assert.Equal(t, countries.GetCurrency("EUR"), currencies[0].Currency)
+		assert.True(t, currencies[0].Primary)
+	}
+}
+
+func TestCountriesUsingSecondaryCurrency(t *testing.T) {
+	alpha2 := func(list []*countries.Country) []string {
+		result := make([]string, len(list))
+		for i, c := range list {
+			result[i] = c.Alpha2
+		}
+		return result
+	}
+	assert.Equal(t, []string{"LS", "NA", "ZA"}, alpha2(countries.CountriesUsingCurrency("ZAR")))
+	assert.Contains(t, alpha2(countries.CountriesUsingCurrency("USD")), "PA")
+	assert.Contains(t, alpha2(countries.CountriesUsingCurrency("RUB")), "TJ")
+	assert.Equal(t, []string{"EG", "PS"}, alpha2(countries.CountriesUsingCurrency("EGP")))
+	// The symbol of a secondary currency is the local one.
+	assert.Equal(t, "$1,234.56", countries.FormatMoney(123456, countries.GetCurrency("USD"), "en-PA"))
+}
+
+func ExampleCountry_Currency() {
+	c := countries.Get("JP")
+	currency := c.Currency()
+	fmt.Println(currency.Code, currency.Number, currency.MinorUnits)
+	fmt.Println(currency.Name)
+	fmt.Println(currency.Symbol, currency.NarrowSymbol)
+	// Output:
+	// JPY 392 0
+	// Japanese Yen
+	// JP¥ ¥
+}
@@ -0,0 +1,332 @@
+package countries
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultFuzzyThreshold is the minimum score a candidate must reach to be
+// returned by FuzzyFind.
+const DefaultFuzzyThreshold = 0.8
+
+// FuzzyMatch is a country or a subdivision found by FuzzyMatcher. If the match
+// is a country Subdivision is a zero value Subdivision. Name is the matched name
+// as it appears in the data, Locale is its locale (empty if unknown) and Score
+// is the similarity between the query and Name, from 0 to 1.
+type FuzzyMatch struct {
+	Country     *Country
+	Subdivision Subdivision
+	Name        string
+	Locale      string
+	Score       float64
+}
+
+// IsSubdivision returns true if the match is a subdivision.
+func (m FuzzyMatch) IsSubdivision() bool {
+	return m.Subdivision.Code != ""
+}
+
+type fuzzyEntry struct {
+	key         string
+	runes       []rune
+	trigrams    int
+	country     *Country
+	subdivision string
+	name        string
+	locale      string
+}
+
+// FuzzyMatcher is a typo tolerant matcher over all country and subdivision
+// names. A FuzzyMatcher is expensive to build, so build it once and reuse it:
+// it is safe for concurrent use.
+type FuzzyMatcher struct {
+	// Threshold is the minimum score a candidate must reach to be returned.
+	Threshold float64
+
+	entries  []fuzzyEntry
+	trigrams map[trigram][]int32
+	counters sync.Pool
+}
+
+// NewFuzzyMatcher returns a FuzzyMatcher indexing the names of all countries
+// and their subdivisions, with DefaultFuzzyThreshold.
+func NewFuzzyMatcher() *FuzzyMatcher {
+	m := &FuzzyMatcher{
+		Threshold: DefaultFuzzyThreshold,
+		trigrams:  make(map[trigram][]int32),
+	}
+	m.counters.New = func() interface{} {
+		return make([]uint16, len(m.entries))
+	}
+	for i := range Data.All {
+		c := &Data.All[i]
+		names := newFuzzyNames()
+		names.add(c.ISOShortName, "en")
+		names.add(c.ISOLongName, "en")
+		for _, locale := range sortedKeys(c.Translations) {
+			names.add(c.Translations[locale], locale)
+		}
+		for _, name := range c.UnofficialNames {
+			names.add(name, "")
+		}
+		m.addAll(c, "", names)
+		for _, code := range sortedSubdivisionCodes(c.Subdivisions) {
+			s := c.Subdivisions[code]
+			names := newFuzzyNames()
+			names.add(s.Name, "")
+			for _, name := range s.UnofficialNames {
+				names.add(name, "")
+			}
+			for _, locale := range sortedKeys(s.Translations) {
+				names.add(s.Translations[locale], locale)
+			}
+			m.addAll(c, code, names)
+		}
+	}
+	return m
+}
+
+// fuzzyNames collects the distinct normalized names of a single country or
+// subdivision.
+type fuzzyNames struct {
+	keys    []string
+	names   map[string]string
+	locales map[string]string
+}
+
+func newFuzzyNames() *fuzzyNames {
+	return &fuzzyNames{names: make(map[string]string), locales: make(map[string]string)}
+}
+
+func (n *fuzzyNames) add(name, locale string) {
+	key := fuzzyKey(name)
+	if key == "" {
+		return
+	}
+	if _, found := n.names[key]; found {
+		return
+	}
+	n.keys = append(n.keys, key)
+	n.names[key] = name
+	n.locales[key] = locale
+}
+
+func (m *FuzzyMatcher) addAll(c *Country, subdivision string, names *fuzzyNames) {
+	for _, key := range names.keys {
+		id := int32(len(m.entries))
+		keyTrigrams := trigrams(key)
+		m.entries = append(m.entries, fuzzyEntry{
+			key:         key,
+			runes:       []rune(key),
+			trigrams:    len(keyTrigrams),
+			country:     c,
+			subdivision: subdivision,
+			name:        names.names[key],
+			locale:      names.locales[key],
+		})
+		for _, t := range keyTrigrams {
+			m.trigrams[t] = append(m.trigrams[t], id)
+		}
+	}
+}
+
+// Match returns the countries and subdivisions with a name similar to query,
+// sorted by descending score. Each country or subdivision appears at most once
+// with its best scoring name. Only candidates with a score greater than or
+// equal to m.Threshold are returned.
+func (m *FuzzyMatcher) Match(query string) []FuzzyMatch {
+	key := fuzzyKey(query)
+	result := make([]FuzzyMatch, 0)
+	if key == "" {
+		return result
+	}
+	queryTrigrams := trigrams(key)
+	shared := m.counters.Get().([]uint16)
+	defer m.counters.Put(shared)
+	var touched []int32
+	for _, t := range queryTrigrams {
+		for _, id := range m.trigrams[t] {
+			if shared[id] == 0 {
+				touched = append(touched, id)
+			}
+			shared[id]++
+		}
+	}
+
+	queryRunes := []rune(key)
+	type target struct {
+		country     *Country
+		subdivision string
+	}
+	type candidate struct {
+		id    int32
+		score float64
+	}
+	candidates := make(map[target]candidate)
+	for _, id := range touched {
+		count := shared[id]
+		shared[id] = 0
+		e := &m.entries[id]
+		// Cheap trigram Dice coefficient to discard unrelated candidates
+		// before computing the edit distance.
+		dice := 2 * float64(count) / float64(len(queryTrigrams)+e.trigrams)
+		if dice < m.Threshold/2 || !lengthsCompatible(len(queryRunes), len(e.runes), m.Threshold) {
+			continue
+		}
+		score := similarity(queryRunes, e.runes)
+		if score < m.Threshold {
+			continue
+		}
+		t := target{e.country, e.subdivision}
+		if c, found := candidates[t]; found && (c.score > score || (c.score == score && c.id < id)) {
+			continue
+		}
+		candidates[t] = candidate{id, score}
+	}
+	for _, c := range candidates {
+		result = append(result, m.newMatch(&m.entries[c.id], c.score))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.IsSubdivision() != b.IsSubdivision() {
+			return !a.IsSubdivision()
+		}
+		if a.Country.Alpha2 != b.Country.Alpha2 {
+			return a.Country.Alpha2 < b.Country.Alpha2
+		}
+		return a.Subdivision.Code < b.Subdivision.Code
+	})
+	return result
+}
+
+func (m *FuzzyMatcher) newMatch(e *fuzzyEntry, score float64) FuzzyMatch {
+	match := FuzzyMatch{Country: e.country, Name: e.name, Locale: e.locale, Score: score}
+	if e.subdivision != "" {
+		match.Subdivision = e.country.Subdivisions[e.subdivision]
+	}
+	return match
+}
+
+var (
+	defaultFuzzyMatcher     *FuzzyMatcher
+	defaultFuzzyMatcherOnce sync.Once
+)
+
+// FuzzyFind returns the countries and subdivisions with a name similar to query
+// using a FuzzyMatcher with DefaultFuzzyThreshold. The matcher is built on the
+// first call and reused by the following ones.
+func FuzzyFind(query string) []FuzzyMatch {
+	defaultFuzzyMatcherOnce.Do(func() {
+		defaultFuzzyMatcher = NewFuzzyMatcher()
+	})
+	return defaultFuzzyMatcher.Match(query)
+}
+
+// fuzzyNoiseWords are generic words that qualify a place name without
+// identifying it, like "region" in "Lombardia region".
+var fuzzyNoiseWords = map[string]bool{
+	"the": true, "region": true, "regione": true, "province": true,
+	"provincia": true, "state": true, "county": true, "department": true,
+	"departement": true, "prefecture": true, "district": true, "canton": true,
+	"oblast": true,
+}
+
+// fuzzyKey returns the normalized name without noise words. If the name is
+// made only of noise words they are kept.
+func fuzzyKey(name string) string {
+	words := strings.Fields(normalizeName(name))
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if !fuzzyNoiseWords[w] {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == 0 {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// trigram is a sequence of three runes packed in 63 bits.
+type trigram uint64
+
+// trigrams returns the distinct trigrams of s padded with two spaces on both
+// sides.
+func trigrams(s string) []trigram {
+	r := []rune("  " + s + "  ")
+	result := make([]trigram, 0, len(r))
+next:
+	for i := 0; i+3 <= len(r); i++ {
+		t := trigram(r[i])<<42 | trigram(r[i+1])<<21 | trigram(r[i+2])
+		for _, seen := range result {
+			if seen == t {
+				continue next
+			}
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// lengthsCompatible returns false if two strings with lengths a and b cannot
+// reach the similarity threshold, since their distance is at least the
+// difference of their lengths.
+func lengthsCompatible(a, b int, threshold float64) bool {
+	diff, longest := a-b, a
+	if diff < 0 {
+		diff, longest = -diff, b
+	}
+	return float64(diff) <= (1-threshold)*float64(longest)
+}
+
+// similarity returns 1 minus the optimal string alignment distance (Levenshtein
+// with adjacent transpositions) between a and b normalized by the length of the
+// longest one.
+func similarity(a, b []rune) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(osaDistance(a, b))/float64(longest)
+}
+
+func osaDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyFind(t *testing.T) {
+	matches := countries.FuzzyFind("Untied States")
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "US", matches[0].Country.Alpha2)
+		assert.False(t, matches[0].IsSubdivision())
+		assert.Less(t, matches[0].Score, 1.0)
+	}
+
+	matches = countries.FuzzyFind("Deutchland")
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "DE", matches[0].Country.Alpha2)
+		assert.Equal(t, "Deutschland", matches[0].Name)
+	}
+
+	matches = countries.FuzzyFind("Lombardia region")
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "IT", matches[0].Country.Alpha2)
+		assert.True(t, matches[0].IsSubdivision())
+		assert.Equal(t, "25", matches[0].Subdivision.Code)
+		assert.Equal(t, 1.0, matches[0].Score)
+	}
+
+	assert.Empty(t, countries.FuzzyFind("Xyzzy"))
+	assert.Empty(t, countries.FuzzyFind(""))
+}
+
+func TestFuzzyMatcherThreshold(t *testing.T) {
+	m := countries.NewFuzzyMatcher()
+	m.Threshold = 1
+	assert.Empty(t, m.Match("Itly"))
+	m.Threshold = 0.7
+	matches := m.Match("Itly")
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "IT", matches[0].Country.Alpha2)
+	}
+	for _, match := range matches {
+		assert.GreaterOrEqual(t, match.Score, 0.7)
+	}
+}
+
+func BenchmarkFuzzyFind(b *testing.B) {
+	countries.FuzzyFind("Italy")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		countries.FuzzyFind("Untied States")
+	}
+}
+
+func ExampleFuzzyFind() {
+	m := countries.FuzzyFind("Deutchland")[0]
+	fmt.Println(m.Country.Alpha2)
+	// Output: DE
+}
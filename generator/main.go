@@ -82,6 +82,7 @@ func main() {
 			if subdivision.Type == "metropolitan_city" && subdivision.Translations["en"] == c.Capital {
 				subdivision.Capital = true
 			}
+			subdivision.CountryAlpha2 = countryAlpha2
 			c.Subdivisions[code] = *subdivision
 		}
 		c.Timezones = allTimezones[countryAlpha2]
@@ -109,17 +110,8 @@ func main() {
 	}
 	g.Printf("}\n")
 
-	g.Printf("\n")
-	g.Printf("// Get returns the country identified by alpha2 code.\n")
-	g.Printf("func Get(alpha2 string) *Country {\n")
-	g.Printf("  switch alpha2 {\n")
-	for i, country := range all {
-		g.Printf("  case \"%s\":\n", country.Alpha2)
-		g.Printf("    return &All[%d]\n", i)
-	}
-	g.Printf("  }\n")
-	g.Printf("  return nil\n")
-	g.Printf("}\n")
+	// Countries are looked up by code through the indexes of CountryData, see
+	// Get in lookup.go, so no lookup function is generated.
 
 	g.Printf("\n")
 	g.Printf("// Alpha2 is a slice with all country alpha2 codes.\n")
@@ -237,7 +229,15 @@ func loadTimezones(timezonesPath string, out map[string][]string) error {
 }
 
 func countryToCodeString(c countries.Country) string {
+	// %#v prints the address of pointers, so EUVATMembership is written as
+	// the address of a bool literal.
+	euvatMembership := "nil"
+	if c.EUVATMembership != nil {
+		euvatMembership = fmt.Sprintf("&[]bool{%t}[0]", *c.EUVATMembership)
+	}
+	c.EUVATMembership = nil
 	s := fmt.Sprintf("%#v", c)
+	s = strings.Replace(s, "EUVATMembership:(*bool)(nil)", "EUVATMembership:"+euvatMembership, 1)
 	s = strings.ReplaceAll(s, "countries.", "")
 	s = strings.ReplaceAll(s, "Country{", "{")
 	s = strings.ReplaceAll(s, ":Subdivision{", ":{")
@@ -0,0 +1,115 @@
+package countries
+
+import (
+	"strings"
+)
+
+// codeIndex maps a country code to the country it identifies.
+type codeIndex map[string]*Country
+
+// buildCodeIndex returns an index of all countries by the code returned by
+// key. Countries with an empty code are not indexed.
+func buildCodeIndex(all []Country, key func(*Country) string) codeIndex {
+	index := make(codeIndex, len(all))
+	for i := range all {
+		code := key(&all[i])
+		if code == "" {
+			continue
+		}
+		if _, found := index[code]; !found {
+			index[code] = &all[i]
+		}
+	}
+	return index
+}
+
+// normalizeCode trims spaces and upper-cases a country code.
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// normalizeNumericCode trims spaces and left pads with zeros a numeric country
+// code, so that "36" and "036" identify the same country.
+func normalizeNumericCode(code string) string {
+	code = strings.TrimSpace(code)
+	if len(code) > 0 && len(code) < 3 {
+		code = strings.Repeat("0", 3-len(code)) + code
+	}
+	return code
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the country identified by ISO 3166-1 alpha2 code. The code is
+// case insensitive. If the code is not found returns nil.
+func Get(alpha2 string) *Country {
+	return Data.byAlpha2[normalizeCode(alpha2)]
+}
+
+// GetByAlpha3 returns the country identified by ISO 3166-1 alpha3 code. The
+// code is case insensitive. If the code is not found returns nil.
+func GetByAlpha3(alpha3 string) *Country {
+	return Data.byAlpha3[normalizeCode(alpha3)]
+}
+
+// GetByNumeric returns the country identified by ISO 3166-1 numeric code. Codes
+// shorter than three digits are left padded with zeros. If the code is not
+// found returns nil.
+func GetByNumeric(number string) *Country {
+	return Data.byNumeric[normalizeNumericCode(number)]
+}
+
+// GetByIOC returns the country identified by IOC (International Olympic
+// Committee) code. The code is case insensitive. If the code is not found
+// returns nil.
+func GetByIOC(ioc string) *Country {
+	return Data.byIOC[normalizeCode(ioc)]
+}
+
+// GetByGEC returns the country identified by GEC (Geopolitical Entities and
+// Codes, formerly FIPS 10-4) code. The code is case insensitive. If the code is
+// not found returns nil.
+func GetByGEC(gec string) *Country {
+	return Data.byGEC[normalizeCode(gec)]
+}
+
+// GetByUnLocode returns the country identified by the country part of a
+// UN/LOCODE code. The code is case insensitive. If the code is not found
+// returns nil.
+func GetByUnLocode(unLocode string) *Country {
+	return Data.byUnLocode[normalizeCode(unLocode)]
+}
+
+// Lookup returns the country identified by code auto-detecting the code
+// system. Numeric codes are looked up as ISO 3166-1 numeric, two letters codes
+// as alpha2, then UN/LOCODE, then GEC, and three letters codes as alpha3, then
+// IOC. If the code is not found returns nil.
+func Lookup(code string) *Country {
+	code = normalizeCode(code)
+	if isDigits(code) {
+		return GetByNumeric(code)
+	}
+	var lookups []func(string) *Country
+	switch len(code) {
+	case 2:
+		lookups = []func(string) *Country{Get, GetByUnLocode, GetByGEC}
+	case 3:
+		lookups = []func(string) *Country{GetByAlpha3, GetByIOC}
+	}
+	for _, lookup := range lookups {
+		if c := lookup(code); c != nil {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIsCaseInsensitive(t *testing.T) {
+	assert.Equal(t, "IT", countries.Get("it").Alpha2)
+	assert.Equal(t, "IT", countries.Get(" It ").Alpha2)
+	assert.Nil(t, countries.Get(""))
+}
+
+func TestGetByAlpha3(t *testing.T) {
+	assert.Equal(t, "IT", countries.GetByAlpha3("ITA").Alpha2)
+	assert.Equal(t, "DE", countries.GetByAlpha3("deu").Alpha2)
+	assert.Nil(t, countries.GetByAlpha3("XXX"))
+}
+
+func TestGetByNumeric(t *testing.T) {
+	assert.Equal(t, "US", countries.GetByNumeric("840").Alpha2)
+	assert.Equal(t, "AU", countries.GetByNumeric("036").Alpha2)
+	assert.Equal(t, "AU", countries.GetByNumeric("36").Alpha2)
+	assert.Nil(t, countries.GetByNumeric("999"))
+	assert.Nil(t, countries.GetByNumeric(""))
+}
+
+func TestGetByIOC(t *testing.T) {
+	assert.Equal(t, "DE", countries.GetByIOC("GER").Alpha2)
+	assert.Equal(t, "NL", countries.GetByIOC("ned").Alpha2)
+	assert.Nil(t, countries.GetByIOC(""))
+}
+
+func TestGetByGEC(t *testing.T) {
+	assert.Equal(t, "DE", countries.GetByGEC("GM").Alpha2)
+	assert.Equal(t, "GB", countries.GetByGEC("UK").Alpha2)
+	assert.Nil(t, countries.GetByGEC(""))
+}
+
+func TestGetByUnLocode(t *testing.T) {
+	assert.Equal(t, "NO", countries.GetByUnLocode("NO").Alpha2)
+	assert.Nil(t, countries.GetByUnLocode("XX"))
+}
+
+func TestLookup(t *testing.T) {
+	assert.Equal(t, "IT", countries.Lookup("it").Alpha2)
+	assert.Equal(t, "IT", countries.Lookup("ITA").Alpha2)
+	assert.Equal(t, "IT", countries.Lookup("380").Alpha2)
+	assert.Equal(t, "DE", countries.Lookup("GER").Alpha2)
+	// Alpha2 wins over GEC
+	assert.Equal(t, "GM", countries.Lookup("GM").Alpha2)
+	assert.Equal(t, "GB", countries.Lookup("UK").Alpha2)
+	assert.Nil(t, countries.Lookup("XXXX"))
+	assert.Nil(t, countries.Lookup(""))
+
+	for _, c := range countries.Data.All {
+		assert.Equal(t, c.Alpha2, countries.Lookup(c.Alpha2).Alpha2)
+		assert.Equal(t, c.Alpha2, countries.Lookup(c.Alpha3).Alpha2)
+		assert.Equal(t, c.Alpha2, countries.Lookup(c.Number).Alpha2)
+	}
+}
+
+func ExampleLookup() {
+	fmt.Println(countries.Lookup("USA").Alpha2)
+	fmt.Println(countries.Lookup("840").Alpha2)
+	fmt.Println(countries.Lookup("GER").Alpha2)
+	// Output:
+	// US
+	// US
+	// DE
+}
@@ -0,0 +1,178 @@
+package countries
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// moneyFormat describes how a locale writes an amount of money. The symbol
+// goes before the number if prefix is true, otherwise after it, separated by
+// space. A symbol with a letter next to the number, like "KWD", is always
+// separated by a no-break space. Groups have three digits, but the ones before the last group have
+// secondaryGroup digits if it is not zero, like in the Indian numbering system,
+// and numbers with less than minGrouping digits before the last group are not
+// grouped.
+type moneyFormat struct {
+	decimal        string
+	group          string
+	prefix         bool
+	space          string
+	minGrouping    int
+	secondaryGroup int
+}
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+// moneyFormats are the money formats by locale. A locale without a region
+// stands for the region in moneyFormatRegions.
+var moneyFormats = map[string]moneyFormat{
+	"da":    {decimal: ",", group: ".", space: nbsp},
+	"de":    {decimal: ",", group: ".", space: nbsp},
+	"de-AT": {decimal: ",", group: nbsp, prefix: true, space: nbsp},
+	"de-CH": {decimal: ".", group: "’", prefix: true, space: nbsp},
+	"en":    {decimal: ".", group: ",", prefix: true},
+	"en-IN": {decimal: ".", group: ",", prefix: true, secondaryGroup: 2},
+	"en-ZA": {decimal: ",", group: nbsp, prefix: true},
+	"es":    {decimal: ",", group: ".", space: nbsp, minGrouping: 2},
+	"es-MX": {decimal: ".", group: ",", prefix: true},
+	"es-US": {decimal: ".", group: ",", prefix: true},
+	"fr":    {decimal: ",", group: narrowNbsp, space: nbsp},
+	"fr-CA": {decimal: ",", group: nbsp, space: nbsp},
+	"fr-CH": {decimal: ",", group: narrowNbsp, space: nbsp},
+	"hi":    {decimal: ".", group: ",", prefix: true, secondaryGroup: 2},
+	"it":    {decimal: ",", group: ".", space: nbsp},
+	"it-CH": {decimal: ".", group: "’", prefix: true, space: nbsp},
+	"ja":    {decimal: ".", group: ",", prefix: true},
+	"ko":    {decimal: ".", group: ",", prefix: true},
+	"nb":    {decimal: ",", group: nbsp, space: nbsp},
+	"nl":    {decimal: ",", group: ".", prefix: true, space: nbsp},
+	"pl":    {decimal: ",", group: nbsp, space: nbsp, minGrouping: 2},
+	"pt":    {decimal: ",", group: ".", prefix: true, space: nbsp},
+	"pt-PT": {decimal: ",", group: nbsp, space: nbsp, minGrouping: 2},
+	"ru":    {decimal: ",", group: nbsp, space: nbsp},
+	"sv":    {decimal: ",", group: nbsp, space: nbsp},
+	"tr":    {decimal: ",", group: ".", prefix: true},
+	"zh":    {decimal: ".", group: ",", prefix: true},
+}
+
+// moneyFormatRegions are the regions of the locales without a region, used to
+// decide whether a currency is the local one.
+var moneyFormatRegions = map[string]string{
+	"da": "DK", "de": "DE", "en": "US", "es": "ES", "fr": "FR", "hi": "IN",
+	"it": "IT", "ja": "JP", "ko": "KR", "nb": "NO", "nl": "NL", "pl": "PL",
+	"pt": "BR", "ru": "RU", "sv": "SE", "tr": "TR", "zh": "CN",
+}
+
+// parseLocale splits a locale like "en-IE" or "en_IE" into the lower cased
+// language and the upper cased region.
+func parseLocale(locale string) (string, string) {
+	language, region, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	return strings.ToLower(language), strings.ToUpper(region)
+}
+
+// moneyFormatFor returns the money format of locale and its region. Unknown
+// locales fall back to the format of their language and then to "en".
+func moneyFormatFor(locale string) (moneyFormat, string) {
+	language, region := parseLocale(locale)
+	if _, found := moneyFormats[language]; !found {
+		language = "en"
+	}
+	if region == "" {
+		region = moneyFormatRegions[language]
+	}
+	if f, found := moneyFormats[language+"-"+region]; found {
+		return f, region
+	}
+	return moneyFormats[language], region
+}
+
+// FormatMoney returns the amount of money amountMinorUnits, expressed in the
+// minor units of currency (like cents), written as in locale, like "1.234,56 €"
+// for 123456 EUR in "de", "€1,234.56" in "en-IE" or "¥1,235" for 1235 JPY in
+// "ja". The number has currency.MinorUnits fraction digits and the separators
+// are no-break spaces where the locale uses spaces. The symbol is the
+// currency NarrowSymbol if the currency is used in the locale region,
+// otherwise the international Symbol, like "US$" in "en-CA". A symbol with a
+// letter next to the number is separated by a no-break space, like "KWD 0.005"
+// for 5 KWD in "en". Unknown locales fall back to their language and then to
+// "en". If currency is nil, like the result of GetCurrency for an unknown code,
+// returns an empty string.
+func FormatMoney(amountMinorUnits int64, currency *Currency, locale string) string {
+	if currency == nil {
+		return ""
+	}
+	f, region := moneyFormatFor(locale)
+	symbol := currency.Symbol
+	if c := Get(region); c != nil {
+		for _, code := range c.currencyCodes() {
+			if code == currency.Code {
+				symbol = currency.NarrowSymbol
+			}
+		}
+	}
+
+	sign := ""
+	amount := uint64(amountMinorUnits)
+	if amountMinorUnits < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatUint(amount, 10)
+	if len(digits) <= currency.MinorUnits {
+		digits = strings.Repeat("0", currency.MinorUnits-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-currency.MinorUnits], digits[len(digits)-currency.MinorUnits:]
+	number := f.groupDigits(integer)
+	if fraction != "" {
+		number += f.decimal + fraction
+	}
+	// As in CLDR, a letter of the symbol is never attached to the number.
+	space := f.space
+	if f.prefix {
+		if r, _ := utf8.DecodeLastRuneInString(symbol); space == "" && unicode.IsLetter(r) {
+			space = nbsp
+		}
+		return sign + symbol + space + number
+	}
+	if r, _ := utf8.DecodeRuneInString(symbol); space == "" && unicode.IsLetter(r) {
+		space = nbsp
+	}
+	return sign + number + space + symbol
+}
+
+// groupDigits inserts the group separator in the integer part of a number.
+func (f moneyFormat) groupDigits(integer string) string {
+	if len(integer) <= 3 || len(integer) < 3+f.minGrouping {
+		return integer
+	}
+	size := 3
+	if f.secondaryGroup > 0 {
+		size = f.secondaryGroup
+	}
+	last := integer[len(integer)-3:]
+	rest := integer[:len(integer)-3]
+	var groups []string
+	for len(rest) > size {
+		groups = append([]string{rest[len(rest)-size:]}, groups...)
+		rest = rest[:len(rest)-size]
+	}
+	groups = append([]string{rest}, groups...)
+	return strings.Join(append(groups, last), f.group)
+}
+
+// FormatMoney returns the amount of money amountMinorUnits, expressed in the
+// minor units of the country currency, written as in the first official
+// language of the country. See FormatMoney. If the country currency is not
+// known returns an empty string.
+func (c *Country) FormatMoney(amountMinorUnits int64) string {
+	locale := "en-" + c.Alpha2
+	if len(c.LanguagesOfficial) > 0 {
+		locale = c.LanguagesOfficial[0] + "-" + c.Alpha2
+	}
+	return FormatMoney(amountMinorUnits, c.Currency(), locale)
+}
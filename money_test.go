@@ -0,0 +1,83 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMoney(t *testing.T) {
+	eur := countries.GetCurrency("EUR")
+	usd := countries.GetCurrency("USD")
+	tests := []struct {
+		amount   int64
+		currency string
+		locale   string
+		want     string
+	}{
+		{123456, "EUR", "de", "1.234,56\u00a0€"},
+		{123456, "EUR", "en-IE", "€1,234.56"},
+		{123456, "EUR", "en_ie", "€1,234.56"},
+		{1235, "JPY", "ja", "¥1,235"},
+		{1235, "JPY", "en", "JP¥1,235"},
+		{123456, "USD", "en", "$1,234.56"},
+		{123456, "USD", "en-US", "$1,234.56"},
+		{123456, "USD", "en-CA", "US$1,234.56"},
+		{123456, "CAD", "en-CA", "$1,234.56"},
+		{123456, "CAD", "fr-CA", "1\u00a0234,56\u00a0$"},
+		{123456789, "EUR", "fr", "1\u202f234\u202f567,89\u00a0€"},
+		{123456, "EUR", "it", "1.234,56\u00a0€"},
+		{123456, "EUR", "es", "1234,56\u00a0€"},
+		{1234567, "EUR", "es", "12.345,67\u00a0€"},
+		{123456, "EUR", "nl", "€\u00a01.234,56"},
+		{123456, "EUR", "de-AT", "€\u00a01\u00a0234,56"},
+		{123456, "CHF", "de-CH", "CHF\u00a01’234.56"},
+		{12345678, "INR", "hi", "₹1,23,456.78"},
+		{12345678, "INR", "en-IN", "₹1,23,456.78"},
+		{123456, "BRL", "pt", "R$\u00a01.234,56"},
+		{123456, "GBP", "en-GB", "£1,234.56"},
+		{1234567, "KWD", "en", "KWD\u00a01,234.567"},
+		{5, "KWD", "en", "KWD\u00a00.005"},
+		{123456, "CHF", "tr", "CHF\u00a01.234,56"},
+		{-123456, "EUR", "de", "-1.234,56\u00a0€"},
+		{-123456, "EUR", "en-IE", "-€1,234.56"},
+		{5, "EUR", "en-IE", "€0.05"},
+		{0, "EUR", "en-IE", "€0.00"},
+		{0, "JPY", "ja", "¥0"},
+		{123456, "EUR", "xx", "€1,234.56"},
+		{123456, "EUR", "xx-DE", "€1,234.56"},
+		{123456, "USD", "", "$1,234.56"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, countries.FormatMoney(tt.amount, countries.GetCurrency(tt.currency), tt.locale), "%d %s %s", tt.amount, tt.currency, tt.locale)
+	}
+	assert.Equal(t, "-US$92,233,720,368,547,758.08", countries.FormatMoney(-9223372036854775808, usd, "en-IE"))
+	assert.Equal(t, "€92,233,720,368,547,758.07", countries.FormatMoney(9223372036854775807, eur, "en-IE"))
+	assert.Equal(t, "", countries.FormatMoney(100, countries.GetCurrency("XXX"), "en"))
+}
+
+func TestCountryFormatMoney(t *testing.T) {
+	assert.Equal(t, "1.234,56\u00a0€", countries.Get("DE").FormatMoney(123456))
+	assert.Equal(t, "CHF\u00a01’234.56", countries.Get("CH").FormatMoney(123456))
+	assert.Equal(t, "$1,234.56", countries.Get("US").FormatMoney(123456))
+	assert.Equal(t, "¥1,235", countries.Get("JP").FormatMoney(1235))
+	for _, c := range countries.Data.All {
+		assert.NotEmpty(t, c.FormatMoney(123456), c.Alpha2)
+	}
+	c := *countries.Get("IT")
+	c.CurrencyCode = "XXX"
+	assert.Equal(t, "", c.FormatMoney(123456))
+}
+
+func ExampleFormatMoney() {
+	eur := countries.GetCurrency("EUR")
+	fmt.Println(countries.FormatMoney(123456, eur, "en-IE"))
+	fmt.Printf("%q\n", countries.FormatMoney(123456, eur, "de"))
+	fmt.Println(countries.FormatMoney(1235, countries.GetCurrency("JPY"), "ja"))
+	// Output:
+	// €1,234.56
+	// "1.234,56\u00a0€"
+	// ¥1,235
+}
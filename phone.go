@@ -0,0 +1,288 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidPhoneNumber is returned when a phone number contains
+	// characters other than digits and formatting characters, or it has no
+	// country calling code and no default country.
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	// ErrUnknownCallingCode is returned when a phone number starts with a
+	// country calling code not assigned to any country.
+	ErrUnknownCallingCode = errors.New("unknown country calling code")
+	// ErrPhoneNumberLength is returned when the national number of a phone
+	// number has a length not used in the country.
+	ErrPhoneNumberLength = errors.New("invalid phone number length")
+)
+
+// PhoneNumber is a phone number split into the country calling code, like
+// "39", and the national significant number, that is the number without the
+// country calling code and the national trunk prefix.
+type PhoneNumber struct {
+	Country        *Country
+	CountryCode    string
+	NationalNumber string
+}
+
+// callingCodeMainCountries are the countries that own a calling code shared
+// with other countries or territories.
+var callingCodeMainCountries = map[string]string{
+	"1":   "US",
+	"7":   "RU",
+	"39":  "IT",
+	"44":  "GB",
+	"47":  "NO",
+	"61":  "AU",
+	"64":  "NZ",
+	"212": "MA",
+	"262": "RE",
+	"358": "FI",
+	"500": "FK",
+	"590": "GP",
+	"599": "CW",
+	"672": "NF",
+}
+
+// buildCallingCodeIndex returns an index of the countries by calling code. A
+// shared calling code identifies the country that owns it.
+func buildCallingCodeIndex(all []Country) codeIndex {
+	index := buildCodeIndex(all, func(c *Country) string { return c.CountryCode })
+	for i := range all {
+		if callingCodeMainCountries[all[i].CountryCode] == all[i].Alpha2 {
+			index[all[i].CountryCode] = &all[i]
+		}
+	}
+	return index
+}
+
+// buildPhonePrefixIndex returns an index of the countries by phone prefix,
+// from the countries NANPPrefix and from prefixes.
+func buildPhonePrefixIndex(all []Country, prefixes map[string][]string) codeIndex {
+	index := make(codeIndex)
+	for i := range all {
+		for _, prefix := range all[i].phonePrefixes(prefixes) {
+			index[prefix] = &all[i]
+		}
+	}
+	return index
+}
+
+// phonePrefixes returns the phone prefixes identifying the country among the
+// countries sharing its calling code.
+func (c *Country) phonePrefixes(prefixes map[string][]string) []string {
+	return append(c.NANPPrefixes(), prefixes[c.Alpha2]...)
+}
+
+// callingCode returns the country calling code at the beginning of digits. If
+// digits do not start with an assigned calling code returns an empty string.
+func callingCode(digits string) string {
+	for n := 3; n > 0; n-- {
+		if len(digits) > n && Data.byCallingCode[digits[:n]] != nil {
+			return digits[:n]
+		}
+	}
+	return ""
+}
+
+// phonePrefixCountry returns the country of the longest phone prefix matching
+// the national number of the calling code, or nil if no prefix matches.
+func phonePrefixCountry(code, national string) *Country {
+	number := code + national
+	for n := len(number); n > len(code); n-- {
+		if c := Data.byPhonePrefix[number[:n]]; c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// resolvePhoneCountry returns the country of the national number of the
+// calling code. If no phone prefix matches, defaultCountry is returned if it
+// uses the calling code without prefixes of its own, otherwise the country
+// owning the calling code.
+func resolvePhoneCountry(code, national string, defaultCountry *Country) *Country {
+	if c := phonePrefixCountry(code, national); c != nil {
+		return c
+	}
+	if defaultCountry != nil && defaultCountry.CountryCode == code && len(defaultCountry.phonePrefixes(Data.phonePrefixes)) == 0 {
+		return defaultCountry
+	}
+	return Data.byCallingCode[code]
+}
+
+// CountryByPhonePrefix returns the country of the international phone number,
+// like "+1 876 555 1234", with or without the leading "+". The longest phone
+// prefix (the calling code followed by an area or a mobile network code)
+// decides among the countries sharing a calling code, like Jamaica and the
+// United States in the North American Numbering Plan or Russia and Kazakhstan
+// for +7; numbers without a specific prefix belong to the country owning the
+// calling code. If the calling code is not assigned returns nil.
+func CountryByPhonePrefix(number string) *Country {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, number)
+	code := callingCode(digits)
+	if code == "" {
+		return nil
+	}
+	return resolvePhoneCountry(code, digits[len(code):], nil)
+}
+
+// CountriesByCallingCode returns the countries using the calling code, like
+// "44" or "+44": the country owning the calling code first, then the others
+// sorted by alpha2 code. If the calling code is not assigned returns an empty
+// slice.
+func CountriesByCallingCode(code string) []*Country {
+	code = strings.TrimPrefix(strings.TrimSpace(code), "+")
+	result := make([]*Country, 0)
+	main := Data.byCallingCode[code]
+	if main == nil {
+		return result
+	}
+	result = append(result, main)
+	for i := range Data.All {
+		if c := &Data.All[i]; c.CountryCode == code && c != main {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// phonePrefix returns prefix, or an empty string if prefix is the "None"
+// placeholder used in the data.
+func phonePrefix(prefix string) string {
+	if prefix == "None" {
+		return ""
+	}
+	return prefix
+}
+
+// phoneDigits returns the digits of input and whether it starts with "+". Spaces
+// and the characters ()-./ are ignored, as well as a trunk prefix written as
+// "(0)" after the country calling code.
+func phoneDigits(input string) (string, bool, error) {
+	input = strings.TrimSpace(input)
+	if i := strings.Index(input, "(0)"); i > 0 {
+		input = input[:i] + input[i+3:]
+	}
+	plus := strings.HasPrefix(input, "+")
+	if plus {
+		input = input[1:]
+	}
+	var b strings.Builder
+	for _, r := range input {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '(' || r == ')' || r == '-' || r == '.' || r == '/' || r == ' ':
+		default:
+			return "", false, fmt.Errorf("%w: unexpected character %q in %q", ErrInvalidPhoneNumber, r, input)
+		}
+	}
+	if b.Len() == 0 {
+		return "", false, fmt.Errorf("%w: no digits in %q", ErrInvalidPhoneNumber, input)
+	}
+	return b.String(), plus, nil
+}
+
+// ParsePhone parses a phone number written in international form, starting
+// with "+" or with the international prefix of defaultCountry, or in national
+// form, that is a number of defaultCountry with or without the national trunk
+// prefix. The country of a calling code shared by more countries is resolved
+// by the longest phone prefix as in CountryByPhonePrefix. Without a matching
+// prefix, a local number in national form (like "555 1234") is of
+// defaultCountry, while a whole national number is of defaultCountry only if
+// it uses the calling code without prefixes of its own, otherwise of the
+// country owning the calling code: so "(212) 555-1234" is a US number also
+// when defaultCountry is Jamaica. Formatting characters like spaces, dashes,
+// dots and parentheses are ignored. If the number is in national form and
+// defaultCountry is nil returns ErrInvalidPhoneNumber; if the country calling
+// code is not assigned returns ErrUnknownCallingCode. The parsed number is not
+// validated: see Validate.
+func ParsePhone(input string, defaultCountry *Country) (PhoneNumber, error) {
+	digits, international, err := phoneDigits(input)
+	if err != nil {
+		return PhoneNumber{}, err
+	}
+	if !international && defaultCountry != nil {
+		prefix := phonePrefix(defaultCountry.InternationalPrefix)
+		if prefix != "" && strings.HasPrefix(digits, prefix) {
+			digits = digits[len(prefix):]
+			international = true
+		}
+	}
+
+	if !international {
+		if defaultCountry == nil {
+			return PhoneNumber{}, fmt.Errorf("%w: missing country calling code in %q", ErrInvalidPhoneNumber, input)
+		}
+		national := digits
+		if prefix := phonePrefix(defaultCountry.NationalPrefix); prefix != "" && strings.HasPrefix(national, prefix) {
+			if stripped := national[len(prefix):]; defaultCountry.validPhoneLength(stripped) {
+				national = stripped
+			}
+		}
+		// A number in national form is of defaultCountry, unless it has the
+		// phone prefix of another country sharing the calling code, or it is
+		// a whole national number without any of the defaultCountry prefixes.
+		c := defaultCountry
+		if prefixCountry := phonePrefixCountry(defaultCountry.CountryCode, national); prefixCountry != nil {
+			c = prefixCountry
+		} else if len(defaultCountry.NationalNumberLengths) > 0 && defaultCountry.validPhoneLength(national) {
+			c = resolvePhoneCountry(defaultCountry.CountryCode, national, defaultCountry)
+		}
+		return PhoneNumber{Country: c, CountryCode: defaultCountry.CountryCode, NationalNumber: national}, nil
+	}
+
+	code := callingCode(digits)
+	if code == "" {
+		return PhoneNumber{}, fmt.Errorf("%w: %q", ErrUnknownCallingCode, input)
+	}
+	national := digits[len(code):]
+	c := resolvePhoneCountry(code, national, defaultCountry)
+	// Numbers like "+7 8 495 123 45 67" wrongly include the trunk prefix.
+	if prefix := phonePrefix(c.NationalPrefix); prefix != "" && strings.HasPrefix(national, prefix) && !c.validPhoneLength(national) {
+		if stripped := national[len(prefix):]; c.validPhoneLength(stripped) {
+			national = stripped
+		}
+	}
+	return PhoneNumber{Country: c, CountryCode: code, NationalNumber: national}, nil
+}
+
+// validPhoneLength returns true if national has one of the country national
+// number lengths, or if the lengths are not known.
+func (c *Country) validPhoneLength(national string) bool {
+	if len(c.NationalNumberLengths) == 0 {
+		return true
+	}
+	for _, l := range c.NationalNumberLengths {
+		if len(national) == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns nil if the phone number is valid: the country is known, the
+// national number is made only of digits and its length is one of the country
+// NationalNumberLengths. Otherwise returns ErrInvalidPhoneNumber,
+// ErrUnknownCallingCode or ErrPhoneNumberLength.
+func (p PhoneNumber) Validate() error {
+	if p.Country == nil || p.Country.CountryCode != p.CountryCode {
+		return fmt.Errorf("%w: %q", ErrUnknownCallingCode, p.CountryCode)
+	}
+	if !isDigits(p.NationalNumber) {
+		return fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, p.NationalNumber)
+	}
+	if !p.Country.validPhoneLength(p.NationalNumber) {
+		return fmt.Errorf("%w: %d digits for country %s, expected %v", ErrPhoneNumberLength, len(p.NationalNumber), p.Country.Alpha2, p.Country.NationalNumberLengths)
+	}
+	return nil
+}
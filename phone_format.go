@@ -0,0 +1,112 @@
+package countries
+
+import (
+	"strings"
+)
+
+// PhoneFormat is a style to write a phone number.
+type PhoneFormat int
+
+// Phone number formats.
+const (
+	// PhoneFormatE164 is the E.164 format, like "+390612345678".
+	PhoneFormatE164 PhoneFormat = iota
+	// PhoneFormatInternational is the international format with the digits
+	// grouped, like "+39 06 1234 5678".
+	PhoneFormatInternational
+	// PhoneFormatNational is the format used to dial the number from within
+	// the country, with the national trunk prefix if the country has one, like
+	// "020 7946 0018" for GB. Numbers of the North American Numbering Plan are
+	// written like "(212) 555-1234".
+	PhoneFormatNational
+	// PhoneFormatRFC3966 is the tel URI format of RFC 3966, like
+	// "tel:+39-06-1234-5678".
+	PhoneFormatRFC3966
+)
+
+// Format returns the phone number written in the style f. The first group of
+// digits is the national destination code, whose length depends on the first
+// digits of the number in countries with destination codes of different
+// lengths, like "06" and "0932" in IT. The rest of the number is a single group
+// up to six digits, otherwise it is split in groups of three or four digits.
+func (p PhoneNumber) Format(f PhoneFormat) string {
+	if f == PhoneFormatE164 {
+		return "+" + p.CountryCode + p.NationalNumber
+	}
+	groups := p.groups()
+	switch f {
+	case PhoneFormatNational:
+		if p.Country == nil {
+			break
+		}
+		if p.CountryCode == "1" && len(groups) == 3 {
+			return "(" + groups[0] + ") " + groups[1] + "-" + groups[2]
+		}
+		prefix := phonePrefix(p.Country.NationalPrefix)
+		if prefix == "" {
+			break
+		}
+		// A "0" trunk prefix is written attached to the destination code,
+		// like "020" in GB, others are separated, like "8 495" in RU.
+		if prefix == "0" {
+			groups[0] = prefix + groups[0]
+		} else {
+			groups = append([]string{prefix}, groups...)
+		}
+	case PhoneFormatInternational:
+		groups = append([]string{"+" + p.CountryCode}, groups...)
+	case PhoneFormatRFC3966:
+		return "tel:+" + p.CountryCode + "-" + strings.Join(groups, "-")
+	}
+	return strings.Join(groups, " ")
+}
+
+// groups splits the national number in the national destination code and
+// the groups of the subscriber number.
+func (p PhoneNumber) groups() []string {
+	national := p.NationalNumber
+	var groups []string
+	if l := p.ndcLength(); l > 0 && l < len(national) {
+		groups = append(groups, national[:l])
+		national = national[l:]
+	}
+	return append(groups, groupDigits(national)...)
+}
+
+// ndcLength returns the length of the national destination code of the phone
+// number, from the lengths by prefix of the phone_ndc_lengths.yaml data or from
+// the country NationalDestinationCodeLengths. If the country is not known
+// returns zero.
+func (p PhoneNumber) ndcLength() int {
+	if p.Country == nil {
+		return 0
+	}
+	if lengths, found := Data.phoneNDCLengths[p.Country.Alpha2]; found {
+		for n := len(p.NationalNumber); n >= 0; n-- {
+			if l, found := lengths[p.NationalNumber[:n]]; found {
+				return l
+			}
+		}
+	}
+	if len(p.Country.NationalDestinationCodeLengths) > 0 {
+		return p.Country.NationalDestinationCodeLengths[0]
+	}
+	return 0
+}
+
+// groupDigits splits digits in groups of four digits at most, as even as
+// possible and with the longest groups at the end, like "555 1234". Up to six
+// digits are not split, like "123456".
+func groupDigits(digits string) []string {
+	if len(digits) <= 6 {
+		return []string{digits}
+	}
+	n := (len(digits) + 3) / 4
+	groups := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		size := len(digits) / (n - i)
+		groups = append(groups, digits[:size])
+		digits = digits[size:]
+	}
+	return groups
+}
@@ -0,0 +1,86 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneNumberFormat(t *testing.T) {
+	tests := []struct {
+		input         string
+		e164          string
+		international string
+		national      string
+		rfc3966       string
+	}{
+		{"+39 06 1234 5678", "+390612345678", "+39 06 1234 5678", "06 1234 5678", "tel:+39-06-1234-5678"},
+		{"+39 0932 123456", "+390932123456", "+39 0932 123456", "0932 123456", "tel:+39-0932-123456"},
+		{"+39 011 1234567", "+390111234567", "+39 011 123 4567", "011 123 4567", "tel:+39-011-123-4567"},
+		{"+39 333 123 4567", "+393331234567", "+39 333 123 4567", "333 123 4567", "tel:+39-333-123-4567"},
+		{"+1 212 555 1234", "+12125551234", "+1 212 555 1234", "(212) 555-1234", "tel:+1-212-555-1234"},
+		{"+1 876 555 1234", "+18765551234", "+1 876 555 1234", "(876) 555-1234", "tel:+1-876-555-1234"},
+		{"+44 20 7946 0018", "+442079460018", "+44 20 7946 0018", "020 7946 0018", "tel:+44-20-7946-0018"},
+		{"+44 121 496 0000", "+441214960000", "+44 121 496 0000", "0121 496 0000", "tel:+44-121-496-0000"},
+		{"+44 7400 123456", "+447400123456", "+44 7400 123456", "07400 123456", "tel:+44-7400-123456"},
+		{"+44 1481 123456", "+441481123456", "+44 1481 123456", "01481 123456", "tel:+44-1481-123456"},
+		{"+49 30 12345678", "+493012345678", "+49 30 1234 5678", "030 1234 5678", "tel:+49-30-1234-5678"},
+		{"+49 221 1234567", "+492211234567", "+49 221 123 4567", "0221 123 4567", "tel:+49-221-123-4567"},
+		{"+49 6131 123456", "+496131123456", "+49 6131 123456", "06131 123456", "tel:+49-6131-123456"},
+		{"+49 170 1234567", "+491701234567", "+49 170 123 4567", "0170 123 4567", "tel:+49-170-123-4567"},
+		{"+7 495 123 45 67", "+74951234567", "+7 495 123 4567", "8 495 123 4567", "tel:+7-495-123-4567"},
+		{"+33 1 23 45 67 89", "+33123456789", "+33 1 2345 6789", "01 2345 6789", "tel:+33-1-2345-6789"},
+		{"+33 6 12 34 56 78", "+33612345678", "+33 6 1234 5678", "06 1234 5678", "tel:+33-6-1234-5678"},
+		{"+81 3 1234 5678", "+81312345678", "+81 3 1234 5678", "03 1234 5678", "tel:+81-3-1234-5678"},
+		{"+81 45 123 4567", "+81451234567", "+81 45 123 4567", "045 123 4567", "tel:+81-45-123-4567"},
+		{"+81 90 1234 5678", "+819012345678", "+81 90 1234 5678", "090 1234 5678", "tel:+81-90-1234-5678"},
+		{"+61 8 9123 4567", "+61891234567", "+61 8 9123 4567", "08 9123 4567", "tel:+61-8-9123-4567"},
+		{"+61 8 9162 1234", "+61891621234", "+61 8 9162 1234", "08 9162 1234", "tel:+61-8-9162-1234"},
+		{"+61 412 345 678", "+61412345678", "+61 412 345678", "0412 345678", "tel:+61-412-345678"},
+		{"+43 1 2345678", "+4312345678", "+43 1 234 5678", "01 234 5678", "tel:+43-1-234-5678"},
+		{"+43 316 123456", "+43316123456", "+43 316 123456", "0316 123456", "tel:+43-316-123456"},
+		{"+43 664 1234567", "+436641234567", "+43 664 123 4567", "0664 123 4567", "tel:+43-664-123-4567"},
+		{"+43 2742 12345", "+43274212345", "+43 2742 12345", "02742 12345", "tel:+43-2742-12345"},
+		{"+41 44 668 18 00", "+41446681800", "+41 44 668 1800", "044 668 1800", "tel:+41-44-668-1800"},
+		{"+41 800 123 456", "+41800123456", "+41 800 123456", "0800 123456", "tel:+41-800-123456"},
+		{"+31 20 123 4567", "+31201234567", "+31 20 123 4567", "020 123 4567", "tel:+31-20-123-4567"},
+		{"+31 6 12345678", "+31612345678", "+31 6 1234 5678", "06 1234 5678", "tel:+31-6-1234-5678"},
+		{"+31 343 123456", "+31343123456", "+31 343 123456", "0343 123456", "tel:+31-343-123456"},
+	}
+	for _, tt := range tests {
+		p, err := countries.ParsePhone(tt.input, nil)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.e164, p.Format(countries.PhoneFormatE164), tt.input)
+			assert.Equal(t, tt.international, p.Format(countries.PhoneFormatInternational), tt.input)
+			assert.Equal(t, tt.national, p.Format(countries.PhoneFormatNational), tt.input)
+			assert.Equal(t, tt.rfc3966, p.Format(countries.PhoneFormatRFC3966), tt.input)
+		}
+	}
+
+	for _, c := range countries.Data.All {
+		p := countries.PhoneNumber{Country: &c, CountryCode: c.CountryCode, NationalNumber: "123456789"}
+		assert.NotContains(t, p.Format(countries.PhoneFormatNational), "None", c.Alpha2)
+	}
+
+	p := countries.PhoneNumber{CountryCode: "39", NationalNumber: "12345678"}
+	assert.Equal(t, "+39 1234 5678", p.Format(countries.PhoneFormatInternational))
+	assert.Equal(t, "1234 5678", p.Format(countries.PhoneFormatNational))
+}
+
+func ExamplePhoneNumber_Format() {
+	p, err := countries.ParsePhone("020 7946 0018", countries.Get("GB"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(p.Format(countries.PhoneFormatE164))
+	fmt.Println(p.Format(countries.PhoneFormatInternational))
+	fmt.Println(p.Format(countries.PhoneFormatNational))
+	fmt.Println(p.Format(countries.PhoneFormatRFC3966))
+	// Output:
+	// +442079460018
+	// +44 20 7946 0018
+	// 020 7946 0018
+	// tel:+44-20-7946-0018
+}
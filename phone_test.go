@@ -0,0 +1,215 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		input          string
+		defaultCountry string
+		country        string
+		countryCode    string
+		nationalNumber string
+	}{
+		{"+39 06 1234567", "", "IT", "39", "061234567"},
+		{"+39 06 1234 5678", "", "IT", "39", "0612345678"},
+		{"+39 333 123 4567", "", "IT", "39", "3331234567"},
+		{"0039 06 1234567", "IT", "IT", "39", "061234567"},
+		{"06 1234567", "IT", "IT", "39", "061234567"},
+		{"+1 (212) 555-1234", "", "US", "1", "2125551234"},
+		{"1-212-555-1234", "US", "US", "1", "2125551234"},
+		{"212.555.1234", "US", "US", "1", "2125551234"},
+		{"011 44 20 7946 0018", "US", "GB", "44", "2079460018"},
+		{"+44 (0)20 7946 0018", "", "GB", "44", "2079460018"},
+		{"020 7946 0018", "GB", "GB", "44", "2079460018"},
+		{"+49 30 12345678", "", "DE", "49", "3012345678"},
+		{"030/12345678", "DE", "DE", "49", "3012345678"},
+		{"+33 1 23 45 67 89", "", "FR", "33", "123456789"},
+		{"01 23 45 67 89", "FR", "FR", "33", "123456789"},
+		{"+81 3-1234-5678", "", "JP", "81", "312345678"},
+		{"8 495 123 45 67", "RU", "RU", "7", "4951234567"},
+		{"+7 727 123 4567", "KZ", "KZ", "7", "7271234567"},
+		{"876 555 1234", "JM", "JM", "1", "8765551234"},
+		{"+7 495 123 4567", "", "RU", "7", "4951234567"},
+		{"+7 8 495 123 45 67", "", "RU", "7", "4951234567"},
+	}
+	for _, tt := range tests {
+		p, err := countries.ParsePhone(tt.input, countries.Get(tt.defaultCountry))
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.country, p.Country.Alpha2, tt.input)
+			assert.Equal(t, tt.countryCode, p.CountryCode, tt.input)
+			assert.Equal(t, tt.nationalNumber, p.NationalNumber, tt.input)
+			assert.NoError(t, p.Validate(), tt.input)
+		}
+	}
+
+	// Local numbers without the area code are of the default country.
+	p, err := countries.ParsePhone("555 1234", countries.Get("JM"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "JM", p.Country.Alpha2)
+		assert.Equal(t, "5551234", p.NationalNumber)
+	}
+
+	_, err = countries.ParsePhone("06 1234567", nil)
+	assert.ErrorIs(t, err, countries.ErrInvalidPhoneNumber)
+	_, err = countries.ParsePhone("+39 06 CALL ME", nil)
+	assert.ErrorIs(t, err, countries.ErrInvalidPhoneNumber)
+	_, err = countries.ParsePhone("+", nil)
+	assert.ErrorIs(t, err, countries.ErrInvalidPhoneNumber)
+	_, err = countries.ParsePhone("+999 1234", nil)
+	assert.ErrorIs(t, err, countries.ErrUnknownCallingCode)
+}
+
+func TestPhoneNumberValidate(t *testing.T) {
+	p, err := countries.ParsePhone("+1 212 555 123", nil)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, p.Validate(), countries.ErrPhoneNumberLength)
+	p, err = countries.ParsePhone("+39 06 1234 5678", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Validate())
+	p, err = countries.ParsePhone("+39 06 123", nil)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, p.Validate(), countries.ErrPhoneNumberLength)
+	p, err = countries.ParsePhone("+44 1481 123456", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Validate())
+	p, err = countries.ParsePhone("+44 1481 1234", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "GG", p.Country.Alpha2)
+	assert.ErrorIs(t, p.Validate(), countries.ErrPhoneNumberLength)
+	p, err = countries.ParsePhone("01534 123456", countries.Get("JE"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1534123456", p.NationalNumber)
+	assert.NoError(t, p.Validate())
+
+	p = countries.PhoneNumber{Country: countries.Get("IT"), CountryCode: "44", NationalNumber: "061234567"}
+	assert.ErrorIs(t, p.Validate(), countries.ErrUnknownCallingCode)
+	p = countries.PhoneNumber{CountryCode: "39", NationalNumber: "061234567"}
+	assert.ErrorIs(t, p.Validate(), countries.ErrUnknownCallingCode)
+	p = countries.PhoneNumber{Country: countries.Get("IT"), CountryCode: "39", NationalNumber: "06-1234567"}
+	assert.ErrorIs(t, p.Validate(), countries.ErrInvalidPhoneNumber)
+}
+
+func ExampleParsePhone() {
+	p, err := countries.ParsePhone("(212) 555-1234", countries.Get("US"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(p.Country.Alpha2, p.CountryCode, p.NationalNumber)
+	fmt.Println(p.Validate())
+	// Output:
+	// US 1 2125551234
+	// <nil>
+}
+
+func TestCountryByPhonePrefix(t *testing.T) {
+	tests := map[string]string{
+		"+1 876 555 1234":   "JM",
+		"+1 212 555 1234":   "US",
+		"1 416 555 1234":    "CA",
+		"+1 (809) 555-1234": "DO",
+		"+1 849 555 1234":   "DO",
+		"+1 939 555 1234":   "PR",
+		"+7 495 123 45 67":  "RU",
+		"+7 727 123 45 67":  "KZ",
+		"+44 20 7946 0018":  "GB",
+		"+44 1481 123456":   "GG",
+		"+44 1534 123456":   "JE",
+		"+44 1624 123456":   "IM",
+		"+44 7624 123456":   "IM",
+		"+39 06 1234567":    "IT",
+		"+262 262 123456":   "RE",
+		"+262 269 12 34 56": "YT",
+		"+262 639 12 34 56": "YT",
+		"+47 22 12 34 56":   "NO",
+		"+47 79 02 12 34":   "SJ",
+		"+61 2 9876 5432":   "AU",
+		"+61 8 9164 1234":   "CX",
+		"+61 8 9162 1234":   "CC",
+		"+358 9 1234 5678":  "FI",
+		"+358 18 12345":     "AX",
+		"+590 590 81 23 45": "GP",
+		"+590 590 27 12 34": "BL",
+		"+590 590 87 12 34": "MF",
+		"+599 9 123 4567":   "CW",
+		"+599 717 1234":     "BQ",
+		"+599 318 1234":     "BQ",
+		"+672 3 12345":      "NF",
+		"+672 1 12345":      "AQ",
+	}
+	for number, alpha2 := range tests {
+		c := countries.CountryByPhonePrefix(number)
+		if assert.NotNil(t, c, number) {
+			assert.Equal(t, alpha2, c.Alpha2, number)
+		}
+	}
+	assert.Nil(t, countries.CountryByPhonePrefix("+999 1234"))
+	assert.Nil(t, countries.CountryByPhonePrefix(""))
+
+	for _, c := range countries.Data.All {
+		for _, prefix := range c.NANPPrefixes() {
+			assert.Equal(t, c.Alpha2, countries.CountryByPhonePrefix(prefix+"5551234").Alpha2)
+		}
+	}
+}
+
+func TestCountriesByCallingCode(t *testing.T) {
+	alpha2 := func(list []*countries.Country) []string {
+		result := make([]string, len(list))
+		for i, c := range list {
+			result[i] = c.Alpha2
+		}
+		return result
+	}
+	assert.Equal(t, []string{"GB", "GG", "IM", "JE"}, alpha2(countries.CountriesByCallingCode("44")))
+	assert.Equal(t, []string{"RU", "KZ"}, alpha2(countries.CountriesByCallingCode("+7")))
+	assert.Equal(t, []string{"IT", "VA"}, alpha2(countries.CountriesByCallingCode("39")))
+	assert.Equal(t, []string{"DE"}, alpha2(countries.CountriesByCallingCode("49")))
+	assert.Equal(t, 26, len(countries.CountriesByCallingCode("1")))
+	assert.Equal(t, "US", countries.CountriesByCallingCode("1")[0].Alpha2)
+	assert.Empty(t, countries.CountriesByCallingCode("999"))
+	assert.Empty(t, countries.CountriesByCallingCode(""))
+}
+
+func TestParsePhoneSharedCallingCode(t *testing.T) {
+	tests := []struct {
+		input          string
+		defaultCountry string
+		country        string
+	}{
+		{"+1 876 555 1234", "", "JM"},
+		{"(876) 555-1234", "US", "JM"},
+		{"(212) 555-1234", "JM", "US"},
+		{"(212) 555-1234", "UM", "UM"},
+		{"+7 495 123 45 67", "KZ", "RU"},
+		{"8 727 123 45 67", "RU", "KZ"},
+		{"01481 123456", "GB", "GG"},
+		{"+358 18 12345", "FI", "AX"},
+		{"018 12345", "AX", "AX"},
+		{"+262 269 12 34 56", "RE", "YT"},
+		{"0269 12 34 56", "YT", "YT"},
+	}
+	for _, tt := range tests {
+		p, err := countries.ParsePhone(tt.input, countries.Get(tt.defaultCountry))
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.country, p.Country.Alpha2, tt.input)
+			assert.NoError(t, p.Validate(), tt.input)
+		}
+	}
+}
+
+func ExampleCountryByPhonePrefix() {
+	fmt.Println(countries.CountryByPhonePrefix("+1 876 555 1234").ISOShortName)
+	for _, c := range countries.CountriesByCallingCode("+7") {
+		fmt.Println(c.Alpha2)
+	}
+	// Output:
+	// Jamaica
+	// RU
+	// KZ
+}
@@ -0,0 +1,182 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrNoPostalCodeSystem is returned when validating a postal code of a
+	// country that does not have postal codes.
+	ErrNoPostalCodeSystem = errors.New("country does not have postal codes")
+	// ErrPostalCodeMismatch is returned when a postal code does not match the
+	// country postal code format.
+	ErrPostalCodeMismatch = errors.New("postal code does not match the country format")
+	// ErrInvalidPostalCodeFormat is returned when the country postal code
+	// format is not a valid regular expression.
+	ErrInvalidPostalCodeFormat = errors.New("invalid postal code format")
+)
+
+// PostalCodeError describes why a postal code is not valid for a country. Err
+// is, or wraps, one of ErrNoPostalCodeSystem, ErrPostalCodeMismatch and
+// ErrInvalidPostalCodeFormat.
+type PostalCodeError struct {
+	Alpha2     string
+	PostalCode string
+	Err        error
+}
+
+func (e *PostalCodeError) Error() string {
+	return fmt.Sprintf("postal code %q for country %s: %s", e.PostalCode, e.Alpha2, e.Err)
+}
+
+func (e *PostalCodeError) Unwrap() error {
+	return e.Err
+}
+
+// postalCodeRegexp is a compiled and anchored country postal code format, with
+// the unanchored version used to find a postal code in a text.
+type postalCodeRegexp struct {
+	format string
+	regexp *regexp.Regexp
+	search *regexp.Regexp
+	err    error
+}
+
+// compilePostalCodeFormat compiles format so that it matches the whole postal
+// code, and so that it finds a postal code delimited by characters other than
+// letters and digits.
+func compilePostalCodeFormat(format string) postalCodeRegexp {
+	r, err := regexp.Compile(`^(?:` + format + `)$`)
+	if err != nil {
+		return postalCodeRegexp{format: format, err: err}
+	}
+	search := regexp.MustCompile(`(?:^|[^\pL\pN])(` + format + `)(?:$|[^\pL\pN])`)
+	return postalCodeRegexp{format: format, regexp: r, search: search, err: err}
+}
+
+// buildPostalCodeIndex returns the compiled postal code formats of all
+// countries by alpha2 code.
+func buildPostalCodeIndex(all []Country) map[string]postalCodeRegexp {
+	index := make(map[string]postalCodeRegexp)
+	for _, c := range all {
+		if c.HasPostalCode() {
+			index[c.Alpha2] = compilePostalCodeFormat(c.PostalCodeFormat)
+		}
+	}
+	return index
+}
+
+// postalCodeRegexp returns the compiled country postal code format. Formats are
+// compiled once when data is loaded; if the format has been changed since, it
+// is compiled again.
+func (c *Country) postalCodeRegexp() postalCodeRegexp {
+	if Data != nil {
+		if r, found := Data.postalCodes[c.Alpha2]; found && r.format == c.PostalCodeFormat {
+			return r
+		}
+	}
+	return compilePostalCodeFormat(c.PostalCodeFormat)
+}
+
+// ValidatePostalCode returns nil if postalCode has a valid format for the
+// country, otherwise a *PostalCodeError explaining why it is not valid. The
+// whole postal code must match the country PostalCodeFormat.
+func (c *Country) ValidatePostalCode(postalCode string) error {
+	if !c.HasPostalCode() {
+		return &PostalCodeError{Alpha2: c.Alpha2, PostalCode: postalCode, Err: ErrNoPostalCodeSystem}
+	}
+	r := c.postalCodeRegexp()
+	if r.err != nil {
+		return &PostalCodeError{Alpha2: c.Alpha2, PostalCode: postalCode, Err: fmt.Errorf("%w: %s", ErrInvalidPostalCodeFormat, r.err)}
+	}
+	if !r.regexp.MatchString(postalCode) {
+		return &PostalCodeError{Alpha2: c.Alpha2, PostalCode: postalCode, Err: ErrPostalCodeMismatch}
+	}
+	return nil
+}
+
+// postalCodeSeparator tells where the canonical separator of a postal code
+// goes: Separator is inserted before the Position-th character of the compact
+// postal code or, if Position is negative, before the last -Position
+// characters.
+type postalCodeSeparator struct {
+	Position  int
+	Separator string
+}
+
+// postalCodeSeparators are the canonical separators of the countries whose
+// postal code format allows more than one way to write the same postal code.
+var postalCodeSeparators = map[string]postalCodeSeparator{
+	"AS": {-4, "-"}, // ZIP+4
+	"BR": {-3, "-"},
+	"CA": {-3, " "}, // A1A 1A1
+	"CZ": {-2, " "},
+	"GB": {-3, " "}, // Inward code
+	"GG": {-3, " "},
+	"GR": {-2, " "},
+	"GU": {-4, "-"},
+	"IE": {-4, " "}, // Eircode
+	"IM": {-3, " "},
+	"JE": {-3, " "},
+	"JP": {-4, "-"},
+	"LV": {2, "-"}, // LV-1234
+	"MP": {-4, "-"},
+	"MT": {3, " "},
+	"NL": {-2, " "}, // 1234 AB
+	"PL": {2, "-"},
+	"PR": {-4, "-"},
+	"PT": {-3, "-"},
+	"SE": {-2, " "},
+	"SK": {-2, " "},
+	"US": {-4, "-"},
+	"VI": {-4, "-"},
+}
+
+func (s postalCodeSeparator) insert(compact []rune) string {
+	i := s.Position
+	if i < 0 {
+		i += len(compact)
+	}
+	if i <= 0 || i >= len(compact) {
+		return string(compact)
+	}
+	return string(compact[:i]) + s.Separator + string(compact[i:])
+}
+
+// NormalizePostalCode returns the canonical form of postalCode: upper cased,
+// without noise characters and with the separator used by the country, like
+// "SW1A 1AA" for GB, "1234 AB" for NL or "12345-6789" for US. If the postal
+// code is not valid for the country returns the error of ValidatePostalCode.
+func (c *Country) NormalizePostalCode(postalCode string) (string, error) {
+	upper := strings.ToUpper(strings.Join(strings.Fields(postalCode), " "))
+	if !c.HasPostalCode() {
+		return "", c.ValidatePostalCode(upper)
+	}
+	compact := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, upper))
+
+	var candidates []string
+	if separator, found := postalCodeSeparators[c.Alpha2]; found {
+		candidates = append(candidates, separator.insert(compact))
+	}
+	candidates = append(candidates, string(compact), upper)
+	for _, separator := range []string{" ", "-"} {
+		for i := 1; i < len(compact); i++ {
+			candidates = append(candidates, postalCodeSeparator{i, separator}.insert(compact))
+		}
+	}
+	for _, candidate := range candidates {
+		if c.ValidatePostalCode(candidate) == nil {
+			return candidate, nil
+		}
+	}
+	return "", c.ValidatePostalCode(upper)
+}
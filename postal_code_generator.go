@@ -0,0 +1,119 @@
+package countries
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+)
+
+// ExamplePostalCode returns a postal code that satisfies the country
+// PostalCodeFormat, suitable for form placeholders. The result is always the
+// same for a given country. If the country does not have postal codes returns
+// an empty string.
+func (c *Country) ExamplePostalCode() string {
+	return c.RandomPostalCode(rand.New(rand.NewSource(1)))
+}
+
+// RandomPostalCode returns a random postal code that satisfies the country
+// PostalCodeFormat, using rng as source of randomness: the same rng state
+// always produces the same postal code. If the country does not have postal
+// codes returns an empty string.
+func (c *Country) RandomPostalCode(rng *rand.Rand) string {
+	if !c.HasPostalCode() {
+		return ""
+	}
+	re, err := syntax.Parse(c.PostalCodeFormat, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	generateFromRegexp(&b, re, rng)
+	return b.String()
+}
+
+// maxExtraRepetitions is the maximum number of repetitions generated for
+// unbounded repetition operators beyond their minimum.
+const maxExtraRepetitions = 2
+
+// generateFromRegexp writes to b a random string matching re.
+func generateFromRegexp(b *strings.Builder, re *syntax.Regexp, rng *rand.Rand) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(randomRuneInClass(re.Rune, rng))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune('A' + rng.Intn(26)))
+	case syntax.OpCapture:
+		generateFromRegexp(b, re.Sub[0], rng)
+	case syntax.OpStar:
+		repeatFromRegexp(b, re.Sub[0], 0, maxExtraRepetitions, rng)
+	case syntax.OpPlus:
+		repeatFromRegexp(b, re.Sub[0], 1, 1+maxExtraRepetitions, rng)
+	case syntax.OpQuest:
+		repeatFromRegexp(b, re.Sub[0], 0, 1, rng)
+	case syntax.OpRepeat:
+		max := re.Max
+		if max < 0 {
+			max = re.Min + maxExtraRepetitions
+		}
+		repeatFromRegexp(b, re.Sub[0], re.Min, max, rng)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			generateFromRegexp(b, sub, rng)
+		}
+	case syntax.OpAlternate:
+		generateFromRegexp(b, re.Sub[rng.Intn(len(re.Sub))], rng)
+	}
+	// Empty matches and assertions like ^ and $ do not generate anything.
+}
+
+func repeatFromRegexp(b *strings.Builder, re *syntax.Regexp, min, max int, rng *rand.Rand) {
+	n := min + rng.Intn(max-min+1)
+	for i := 0; i < n; i++ {
+		generateFromRegexp(b, re, rng)
+	}
+}
+
+// randomRuneInClass returns a random rune in the character class ranges,
+// preferring printable ASCII characters.
+func randomRuneInClass(ranges []rune, rng *rand.Rand) rune {
+	printable := intersectRanges(ranges, ' '+1, '~')
+	if len(printable) > 0 {
+		ranges = printable
+	}
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	if total == 0 {
+		return 'A'
+	}
+	n := rng.Intn(total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n)
+		}
+		n -= size
+	}
+	return ranges[0]
+}
+
+// intersectRanges returns the character class ranges restricted to lo-hi.
+func intersectRanges(ranges []rune, lo, hi rune) []rune {
+	var result []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		from, to := ranges[i], ranges[i+1]
+		if from < lo {
+			from = lo
+		}
+		if to > hi {
+			to = hi
+		}
+		if from <= to {
+			result = append(result, from, to)
+		}
+	}
+	return result
+}
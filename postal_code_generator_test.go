@@ -0,0 +1,47 @@
+package countries_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamplePostalCode(t *testing.T) {
+	for _, c := range countries.Data.All {
+		example := c.ExamplePostalCode()
+		if !c.HasPostalCode() {
+			assert.Equal(t, "", example, c.Alpha2)
+			continue
+		}
+		assert.True(t, c.MatchPostalCode(example), "%s: %q", c.Alpha2, example)
+		assert.Equal(t, example, c.ExamplePostalCode(), c.Alpha2)
+	}
+}
+
+func TestRandomPostalCode(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for _, c := range countries.Data.All {
+		if !c.HasPostalCode() {
+			continue
+		}
+		for i := 0; i < 50; i++ {
+			postalCode := c.RandomPostalCode(rng)
+			assert.True(t, c.MatchPostalCode(postalCode), "%s: %q", c.Alpha2, postalCode)
+		}
+	}
+
+	gb := countries.Get("GB")
+	a := gb.RandomPostalCode(rand.New(rand.NewSource(7)))
+	b := gb.RandomPostalCode(rand.New(rand.NewSource(7)))
+	assert.Equal(t, a, b)
+}
+
+func ExampleCountry_RandomPostalCode() {
+	c := countries.Get("IT")
+	rng := rand.New(rand.NewSource(1))
+	fmt.Println(c.MatchPostalCode(c.RandomPostalCode(rng)))
+	// Output: true
+}
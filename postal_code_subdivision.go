@@ -0,0 +1,152 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrPostalCodeSubdivisionMismatch is returned when a postal code belongs to a
+// subdivision other than the given one.
+var ErrPostalCodeSubdivisionMismatch = errors.New("postal code does not belong to the subdivision")
+
+// PostalCodeConfidence tells how reliable is the subdivision inferred from a
+// postal code.
+type PostalCodeConfidence int
+
+const (
+	// PostalCodeConfidenceNone means that the subdivision cannot be inferred.
+	PostalCodeConfidenceNone PostalCodeConfidence = iota
+	// PostalCodeConfidenceLow means that the postal code prefix identifies the
+	// subdivision in most but not all cases.
+	PostalCodeConfidenceLow
+	// PostalCodeConfidenceHigh means that the postal code prefix always
+	// identifies the subdivision.
+	PostalCodeConfidenceHigh
+)
+
+// postalCodePrefixes is the postal code prefixes table of a country as stored
+// in data/postal_codes.yaml.
+type postalCodePrefixes struct {
+	Confidence   string              `yaml:"confidence"`
+	Subdivisions map[string][]string `yaml:"subdivisions"`
+}
+
+// postalCodeRange is a range of postal code prefixes with the same length.
+type postalCodeRange struct {
+	from, to    string
+	subdivision string
+}
+
+// postalCodeTable is the compiled postal code prefixes table of a country, with
+// ranges sorted from the longest to the shortest prefix.
+type postalCodeTable struct {
+	confidence PostalCodeConfidence
+	ranges     []postalCodeRange
+}
+
+func buildPostalCodeTables(all map[string]postalCodePrefixes) map[string]postalCodeTable {
+	result := make(map[string]postalCodeTable, len(all))
+	for alpha2, prefixes := range all {
+		table := postalCodeTable{confidence: PostalCodeConfidenceLow}
+		if prefixes.Confidence == "high" {
+			table.confidence = PostalCodeConfidenceHigh
+		}
+		for code, ranges := range prefixes.Subdivisions {
+			for _, r := range ranges {
+				from, to, found := strings.Cut(r, "-")
+				if !found {
+					to = from
+				}
+				table.ranges = append(table.ranges, postalCodeRange{from: from, to: to, subdivision: code})
+			}
+		}
+		sort.Slice(table.ranges, func(i, j int) bool {
+			a, b := table.ranges[i], table.ranges[j]
+			if len(a.from) != len(b.from) {
+				return len(a.from) > len(b.from)
+			}
+			return a.from < b.from
+		})
+		result[alpha2] = table
+	}
+	return result
+}
+
+// lookup returns the subdivision code of the longest range matching the
+// compact postal code.
+func (t postalCodeTable) lookup(compact string) string {
+	for _, r := range t.ranges {
+		if len(compact) < len(r.from) {
+			continue
+		}
+		prefix := compact[:len(r.from)]
+		if prefix >= r.from && prefix <= r.to {
+			return r.subdivision
+		}
+	}
+	return ""
+}
+
+// SubdivisionForPostalCode returns the subdivision the postal code belongs to
+// and how reliable is the inference. Only some countries have a mapping from
+// postal codes to subdivisions (like US, CA, IT, FR, ES and DE). If the postal
+// code is not valid or the subdivision cannot be inferred returns a zero value
+// Subdivision and PostalCodeConfidenceNone.
+func (c *Country) SubdivisionForPostalCode(postalCode string) (Subdivision, PostalCodeConfidence) {
+	table, found := Data.postalCodeSubdivisions[c.Alpha2]
+	if !found {
+		return Subdivision{}, PostalCodeConfidenceNone
+	}
+	normalized, err := c.NormalizePostalCode(postalCode)
+	if err != nil {
+		return Subdivision{}, PostalCodeConfidenceNone
+	}
+	compact := strings.NewReplacer(" ", "", "-", "").Replace(normalized)
+	s, found := c.Subdivisions[table.lookup(compact)]
+	if !found {
+		return Subdivision{}, PostalCodeConfidenceNone
+	}
+	return s, table.confidence
+}
+
+// ValidatePostalCodeSubdivision returns ErrPostalCodeSubdivisionMismatch if
+// the postal code surely does not belong to the subdivision identified by
+// region, a subdivision code or name. A postal code belongs to a subdivision
+// if the inferred subdivision is the same, an ancestor or a descendant of it.
+// If the relation cannot be verified (unknown region, no mapping for the
+// country or a low confidence inference) returns nil.
+func (c *Country) ValidatePostalCodeSubdivision(postalCode, region string) error {
+	inferred, confidence := c.SubdivisionForPostalCode(postalCode)
+	if confidence != PostalCodeConfidenceHigh {
+		return nil
+	}
+	given := c.Subdivision(region)
+	if given.Code == "" {
+		given = c.SubdivisionByName(region)
+	}
+	if given.Code == "" || subdivisionsRelated(inferred, given) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q is in %s, not in %s", ErrPostalCodeSubdivisionMismatch, postalCode, inferred.ISOCode(), given.ISOCode())
+}
+
+// subdivisionsRelated returns true if a and b are the same subdivision or one
+// of them is an ancestor of the other.
+func subdivisionsRelated(a, b Subdivision) bool {
+	if a.Code == b.Code {
+		return true
+	}
+	for _, ancestor := range a.Ancestors() {
+		if ancestor.Code == b.Code {
+			return true
+		}
+	}
+	for _, ancestor := range b.Ancestors() {
+		if ancestor.Code == a.Code {
+			return true
+		}
+	}
+	return false
+}
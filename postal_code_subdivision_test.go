@@ -0,0 +1,78 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubdivisionForPostalCode(t *testing.T) {
+	tests := []struct {
+		alpha2, postalCode, subdivision string
+		confidence                      countries.PostalCodeConfidence
+	}{
+		{"US", "14214", "NY", countries.PostalCodeConfidenceHigh},
+		{"US", "90210-1234", "CA", countries.PostalCodeConfidenceHigh},
+		{"US", "05501", "MA", countries.PostalCodeConfidenceHigh},
+		{"US", "96799", "AS", countries.PostalCodeConfidenceHigh},
+		{"US", "96950", "MP", countries.PostalCodeConfidenceHigh},
+		{"US", "96910", "GU", countries.PostalCodeConfidenceHigh},
+		{"CA", "k1a 0b1", "ON", countries.PostalCodeConfidenceHigh},
+		{"CA", "X0A 0H0", "NU", countries.PostalCodeConfidenceHigh},
+		{"CA", "X1A 2P6", "NT", countries.PostalCodeConfidenceHigh},
+		{"IT", "97011", "RG", countries.PostalCodeConfidenceLow},
+		{"IT", "00184", "RM", countries.PostalCodeConfidenceLow},
+		{"IT", "20900", "MB", countries.PostalCodeConfidenceLow},
+		{"FR", "75008", "75C", countries.PostalCodeConfidenceHigh},
+		{"FR", "20000", "2A", countries.PostalCodeConfidenceHigh},
+		{"FR", "97400", "974", countries.PostalCodeConfidenceHigh},
+		{"FR", "69003", "69M", countries.PostalCodeConfidenceHigh},
+		{"FR", "69100", "69M", countries.PostalCodeConfidenceHigh},
+		{"FR", "69400", "69", countries.PostalCodeConfidenceHigh},
+		{"FR", "69290", "ARA", countries.PostalCodeConfidenceHigh},
+		{"ES", "28001", "M", countries.PostalCodeConfidenceHigh},
+		{"DE", "10115", "BE", countries.PostalCodeConfidenceLow},
+	}
+	for _, test := range tests {
+		s, confidence := countries.Get(test.alpha2).SubdivisionForPostalCode(test.postalCode)
+		assert.Equal(t, test.subdivision, s.Code, test.postalCode)
+		assert.Equal(t, test.confidence, confidence, test.postalCode)
+	}
+
+	s, confidence := countries.Get("IT").SubdivisionForPostalCode("123")
+	assert.Equal(t, "", s.Code)
+	assert.Equal(t, countries.PostalCodeConfidenceNone, confidence)
+	s, confidence = countries.Get("AT").SubdivisionForPostalCode("1010")
+	assert.Equal(t, "", s.Code)
+	assert.Equal(t, countries.PostalCodeConfidenceNone, confidence)
+}
+
+func TestValidatePostalCodeSubdivision(t *testing.T) {
+	us := countries.Get("US")
+	assert.Nil(t, us.ValidatePostalCodeSubdivision("14214", "NY"))
+	assert.Nil(t, us.ValidatePostalCodeSubdivision("14214", "New York"))
+	assert.ErrorIs(t, us.ValidatePostalCodeSubdivision("14214", "CA"), countries.ErrPostalCodeSubdivisionMismatch)
+	assert.Nil(t, us.ValidatePostalCodeSubdivision("14214", "Atlantis"))
+
+	fr := countries.Get("FR")
+	assert.Nil(t, fr.ValidatePostalCodeSubdivision("75008", "IDF"))
+	assert.Nil(t, fr.ValidatePostalCodeSubdivision("67000", "6AE"))
+	assert.ErrorIs(t, fr.ValidatePostalCodeSubdivision("75008", "OCC"), countries.ErrPostalCodeSubdivisionMismatch)
+	assert.Nil(t, fr.ValidatePostalCodeSubdivision("69100", "69M"))
+	// Postal codes shared by the Métropole de Lyon and the Rhône department
+	assert.Nil(t, fr.ValidatePostalCodeSubdivision("69290", "69M"))
+	assert.Nil(t, fr.ValidatePostalCodeSubdivision("69290", "69"))
+	assert.ErrorIs(t, fr.ValidatePostalCodeSubdivision("69290", "75C"), countries.ErrPostalCodeSubdivisionMismatch)
+
+	// Low confidence inferences are never reported as mismatches
+	assert.Nil(t, countries.Get("IT").ValidatePostalCodeSubdivision("97011", "RM"))
+}
+
+func ExampleCountry_SubdivisionForPostalCode() {
+	c := countries.Get("US")
+	s, _ := c.SubdivisionForPostalCode("90210")
+	fmt.Println(s.Name)
+	// Output: California
+}
@@ -0,0 +1,106 @@
+package countries_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPostalCodeIsAnchored(t *testing.T) {
+	it := countries.Get("IT")
+	assert.False(t, it.MatchPostalCode("123456789"))
+	assert.False(t, it.MatchPostalCode("x35018"))
+	assert.False(t, it.MatchPostalCode(""))
+
+	us := countries.Get("US")
+	assert.True(t, us.MatchPostalCode("14214"))
+	assert.True(t, us.MatchPostalCode("14214-1234"))
+	assert.False(t, us.MatchPostalCode("14214-12345"))
+}
+
+func TestValidatePostalCode(t *testing.T) {
+	it := countries.Get("IT")
+	assert.Nil(t, it.ValidatePostalCode("35018"))
+
+	err := it.ValidatePostalCode("3501")
+	assert.ErrorIs(t, err, countries.ErrPostalCodeMismatch)
+	var postalCodeErr *countries.PostalCodeError
+	if assert.True(t, errors.As(err, &postalCodeErr)) {
+		assert.Equal(t, "IT", postalCodeErr.Alpha2)
+		assert.Equal(t, "3501", postalCodeErr.PostalCode)
+	}
+
+	jm := countries.Get("JM")
+	assert.ErrorIs(t, jm.ValidatePostalCode("35018"), countries.ErrNoPostalCodeSystem)
+
+	broken := *it
+	broken.PostalCodeFormat = `(\d{5}`
+	assert.NotPanics(t, func() {
+		assert.ErrorIs(t, broken.ValidatePostalCode("35018"), countries.ErrInvalidPostalCodeFormat)
+	})
+
+	changed := *it
+	changed.PostalCodeFormat = `\d{4}`
+	assert.Nil(t, changed.ValidatePostalCode("3501"))
+
+	for _, c := range countries.Data.All {
+		if c.HasPostalCode() {
+			assert.NotErrorIs(t, c.ValidatePostalCode(""), countries.ErrInvalidPostalCodeFormat, c.Alpha2)
+		}
+	}
+}
+
+func BenchmarkMatchPostalCode(b *testing.B) {
+	gb := countries.Get("GB")
+	for i := 0; i < b.N; i++ {
+		gb.MatchPostalCode("SW1A 1AA")
+	}
+}
+
+func ExampleCountry_ValidatePostalCode() {
+	c := countries.Get("IT")
+	fmt.Println(c.ValidatePostalCode("123456789"))
+	// Output: postal code "123456789" for country IT: postal code does not match the country format
+}
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		alpha2, input, expected string
+	}{
+		{"GB", "sw1a1aa", "SW1A 1AA"},
+		{"GB", " SW1A  1AA ", "SW1A 1AA"},
+		{"GB", "gir0aa", "GIR 0AA"},
+		{"NL", "1234ab", "1234 AB"},
+		{"NL", "1234-AB", "1234 AB"},
+		{"US", "12345 6789", "12345-6789"},
+		{"US", "123456789", "12345-6789"},
+		{"US", "12345", "12345"},
+		{"CA", "k1a0b1", "K1A 0B1"},
+		{"IT", " 35018 ", "35018"},
+		{"FR", "75 008", "75008"},
+		{"PL", "00950", "00-950"},
+		{"LV", "lv1050", "LV-1050"},
+		{"BR", "01310-100", "01310-100"},
+		{"JP", "1000001", "100-0001"},
+	}
+	for _, test := range tests {
+		normalized, err := countries.Get(test.alpha2).NormalizePostalCode(test.input)
+		assert.Nil(t, err, test.input)
+		assert.Equal(t, test.expected, normalized, test.input)
+	}
+
+	_, err := countries.Get("IT").NormalizePostalCode("123456")
+	assert.ErrorIs(t, err, countries.ErrPostalCodeMismatch)
+	_, err = countries.Get("JM").NormalizePostalCode("12345")
+	assert.ErrorIs(t, err, countries.ErrNoPostalCodeSystem)
+}
+
+func ExampleCountry_NormalizePostalCode() {
+	c := countries.Get("GB")
+	postalCode, _ := c.NormalizePostalCode("sw1a1aa")
+	fmt.Println(postalCode)
+	// Output: SW1A 1AA
+}
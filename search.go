@@ -0,0 +1,165 @@
+package countries
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NameSource identifies which country name matched a search.
+type NameSource int
+
+const (
+	// NameSourceISOShortName is the country ISOShortName.
+	NameSourceISOShortName NameSource = iota
+	// NameSourceISOLongName is the country ISOLongName.
+	NameSourceISOLongName
+	// NameSourceTranslation is one of the country Translations.
+	NameSourceTranslation
+	// NameSourceUnofficialName is one of the country UnofficialNames.
+	NameSourceUnofficialName
+)
+
+// NameMatch is a country found by name. Name is the matched name as it appears
+// in the data, Locale is the locale of the matched name ("en" for ISO names,
+// empty for unofficial names) and Source tells which name has matched.
+type NameMatch struct {
+	Country *Country
+	Name    string
+	Locale  string
+	Source  NameSource
+}
+
+// nameIndex maps a normalized name to the countries with that name, sorted by
+// rank.
+type nameIndex map[string][]NameMatch
+
+func buildNameIndex(all []Country) nameIndex {
+	index := make(nameIndex)
+	add := func(c *Country, name, locale string, source NameSource) {
+		key := normalizeName(name)
+		if key == "" {
+			return
+		}
+		for _, m := range index[key] {
+			if m.Country == c {
+				return
+			}
+		}
+		index[key] = append(index[key], NameMatch{Country: c, Name: name, Locale: locale, Source: source})
+	}
+	for i := range all {
+		c := &all[i]
+		add(c, c.ISOShortName, "en", NameSourceISOShortName)
+		add(c, c.ISOLongName, "en", NameSourceISOLongName)
+		for _, locale := range sortedKeys(c.Translations) {
+			add(c, c.Translations[locale], locale, NameSourceTranslation)
+		}
+		for _, name := range c.UnofficialNames {
+			add(c, name, "", NameSourceUnofficialName)
+		}
+	}
+	for _, matches := range index {
+		sortNameMatches(matches)
+	}
+	return index
+}
+
+func sortNameMatches(matches []NameMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Source != matches[j].Source {
+			return matches[i].Source < matches[j].Source
+		}
+		return matches[i].Country.Alpha2 < matches[j].Country.Alpha2
+	})
+}
+
+// FindByName returns the countries whose ISO short name, ISO long name,
+// translations or unofficial names match name. The comparison is case and
+// diacritic insensitive and ignores punctuation. Candidates are ranked by the
+// kind of the matched name (ISO names first, unofficial names last) and each
+// country appears at most once. If no country matches returns an empty slice.
+func FindByName(name string) []NameMatch {
+	matches := Data.byName[normalizeName(name)]
+	result := make([]NameMatch, len(matches))
+	copy(result, matches)
+	return result
+}
+
+// diacriticsFolding maps letters that do not decompose into a base letter plus
+// combining marks to their ASCII replacement.
+var diacriticsFolding = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d",
+	'þ': "th", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "n", 'ĸ': "k",
+}
+
+// latinFolding maps precomposed latin letters with diacritics to their base
+// letter.
+var latinFolding = buildLatinFolding(map[rune]string{
+	'a': "àáâãäåāăąǎǟǡǻȁȃȧạảấầẩẫậắằẳẵặ",
+	'c': "çćĉċč",
+	'd': "ď",
+	'e': "èéêëēĕėęěȅȇȩẹẻẽếềểễệ",
+	'g': "ĝğġģǧǵ",
+	'h': "ĥȟḥ",
+	'i': "ìíîïĩīĭįǐȉȋỉị",
+	'j': "ĵǰ",
+	'k': "ķǩ",
+	'l': "ĺļľŀ",
+	'n': "ñńņňǹ",
+	'o': "òóôõöōŏőơǒǫǭȍȏȫȭȯȱọỏốồổỗộớờởỡợ",
+	'r': "ŕŗřȑȓ",
+	's': "śŝşšșṣ",
+	't': "ţťțṭ",
+	'u': "ùúûüũūŭůűųưǔǖǘǚǜȕȗụủứừửữự",
+	'w': "ŵẁẃẅ",
+	'y': "ýÿŷȳỳỵỷỹ",
+	'z': "źżžẓ",
+})
+
+func buildLatinFolding(letters map[rune]string) map[rune]rune {
+	result := make(map[rune]rune)
+	for base, variants := range letters {
+		for _, r := range variants {
+			result[r] = base
+		}
+	}
+	return result
+}
+
+// normalizeName returns name lower cased, without diacritics and with every
+// sequence of punctuation and spaces replaced by a single space.
+func normalizeName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			if folded, found := latinFolding[r]; found {
+				b.WriteRune(folded)
+			} else if folded, found := diacriticsFolding[r]; found {
+				b.WriteString(folded)
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindByName(t *testing.T) {
+	matches := countries.FindByName("Italy")
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, "IT", matches[0].Country.Alpha2)
+	assert.Equal(t, "en", matches[0].Locale)
+	assert.Equal(t, countries.NameSourceISOShortName, matches[0].Source)
+
+	matches = countries.FindByName("the italian republic")
+	assert.Equal(t, "IT", matches[0].Country.Alpha2)
+	assert.Equal(t, countries.NameSourceISOLongName, matches[0].Source)
+
+	matches = countries.FindByName("Deutschland")
+	assert.Equal(t, "DE", matches[0].Country.Alpha2)
+	assert.Equal(t, "de", matches[0].Locale)
+	assert.Equal(t, countries.NameSourceTranslation, matches[0].Source)
+
+	matches = countries.FindByName("USA")
+	assert.Equal(t, "US", matches[0].Country.Alpha2)
+
+	assert.Empty(t, countries.FindByName("Atlantis"))
+	assert.Empty(t, countries.FindByName(""))
+}
+
+func TestFindByNameIsDiacriticInsensitive(t *testing.T) {
+	for _, name := range []string{"Côte d'Ivoire", "cote d ivoire", "COTE D'IVOIRE"} {
+		matches := countries.FindByName(name)
+		if assert.NotEmpty(t, matches, name) {
+			assert.Equal(t, "CI", matches[0].Country.Alpha2)
+		}
+	}
+	matches := countries.FindByName("Osterreich")
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "AT", matches[0].Country.Alpha2)
+		assert.Equal(t, "Österreich", matches[0].Name)
+	}
+}
+
+func TestFindByNameAllCountries(t *testing.T) {
+	for _, c := range countries.Data.All {
+		matches := countries.FindByName(c.ISOShortName)
+		if assert.NotEmpty(t, matches, c.Alpha2) {
+			assert.Equal(t, c.Alpha2, matches[0].Country.Alpha2)
+		}
+	}
+}
+
+func ExampleFindByName() {
+	m := countries.FindByName("Vereinigte Staaten")[0]
+	fmt.Println(m.Country.Alpha2, m.Locale)
+	// Output: US de
+}
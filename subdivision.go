@@ -0,0 +1,164 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidSubdivisionCode is returned when an ISO 3166-2 code is
+	// malformed.
+	ErrInvalidSubdivisionCode = errors.New("invalid ISO 3166-2 subdivision code")
+	// ErrSubdivisionNotFound is returned when an ISO 3166-2 code is well formed
+	// but does not identify any subdivision.
+	ErrSubdivisionNotFound = errors.New("subdivision not found")
+)
+
+// subdivisionYAML has the same fields of Subdivision but not its UnmarshalYAML
+// method.
+type subdivisionYAML Subdivision
+
+// UnmarshalYAML decodes a subdivision from YAML. In subdivisions data
+// unofficial_names is either a single name or a list of names.
+func (s *Subdivision) UnmarshalYAML(value *yaml.Node) error {
+	err := value.Decode((*subdivisionYAML)(s))
+	if err != nil {
+		return err
+	}
+	var names struct {
+		UnofficialNames yaml.Node `yaml:"unofficial_names"`
+	}
+	err = value.Decode(&names)
+	if err != nil {
+		return err
+	}
+	switch node := names.UnofficialNames; node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!null" && node.Value != "" {
+			s.UnofficialNames = []string{node.Value}
+		}
+	case yaml.SequenceNode:
+		return node.Decode(&s.UnofficialNames)
+	}
+	return nil
+}
+
+// ISOCode returns the full ISO 3166-2 code of the subdivision, like "US-CA". If
+// the subdivision is a zero value Subdivision returns an empty string.
+func (s Subdivision) ISOCode() string {
+	if s.CountryAlpha2 == "" || s.Code == "" {
+		return ""
+	}
+	return s.CountryAlpha2 + "-" + s.Code
+}
+
+// GetSubdivision returns the country and the subdivision identified by the full
+// ISO 3166-2 code isoCode, like "US-CA" or "IT-RM". The code is case
+// insensitive. If the code is malformed returns ErrInvalidSubdivisionCode, if
+// the country or the subdivision do not exist returns ErrSubdivisionNotFound.
+func GetSubdivision(isoCode string) (*Country, Subdivision, error) {
+	countryCode, code, err := parseSubdivisionCode(isoCode)
+	if err != nil {
+		return nil, Subdivision{}, err
+	}
+	c := Get(countryCode)
+	if c == nil {
+		return nil, Subdivision{}, fmt.Errorf("%w: unknown country %q", ErrSubdivisionNotFound, countryCode)
+	}
+	s, found := c.Subdivisions[code]
+	if !found {
+		return c, Subdivision{}, fmt.Errorf("%w: %q", ErrSubdivisionNotFound, isoCode)
+	}
+	return c, s, nil
+}
+
+// parseSubdivisionCode splits a full ISO 3166-2 code into the country alpha2
+// code and the subdivision code.
+func parseSubdivisionCode(isoCode string) (string, string, error) {
+	countryCode, code, found := strings.Cut(normalizeCode(isoCode), "-")
+	if !found || len(countryCode) != 2 || len(code) == 0 || len(code) > 3 {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidSubdivisionCode, isoCode)
+	}
+	for _, r := range countryCode {
+		if r < 'A' || r > 'Z' {
+			return "", "", fmt.Errorf("%w: %q", ErrInvalidSubdivisionCode, isoCode)
+		}
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '~' {
+			return "", "", fmt.Errorf("%w: %q", ErrInvalidSubdivisionCode, isoCode)
+		}
+	}
+	return countryCode, code, nil
+}
+
+// Ancestors returns the parent subdivisions of the subdivision, from the
+// nearest to the top level one. If the subdivision is a top level subdivision
+// returns an empty slice.
+func (s Subdivision) Ancestors() []Subdivision {
+	result := make([]Subdivision, 0)
+	c := Get(s.CountryAlpha2)
+	if c == nil {
+		return result
+	}
+	seen := map[string]bool{s.Code: true}
+	for code := s.Parent; code != "" && !seen[code]; {
+		parent, found := c.Subdivisions[code]
+		if !found {
+			break
+		}
+		seen[code] = true
+		result = append(result, parent)
+		code = parent.Parent
+	}
+	return result
+}
+
+// SubdivisionChildren returns the subdivisions whose parent is the subdivision
+// identified by code, sorted by code.
+func (c *Country) SubdivisionChildren(code string) []Subdivision {
+	return c.filterSubdivisions(func(s Subdivision) bool {
+		return code != "" && s.Parent == code
+	})
+}
+
+// TopLevelSubdivisions returns the subdivisions without a parent, sorted by
+// code. If the hierarchy of the country subdivisions is not known, all
+// subdivisions are top level subdivisions.
+func (c *Country) TopLevelSubdivisions() []Subdivision {
+	return c.filterSubdivisions(func(s Subdivision) bool {
+		return s.Parent == ""
+	})
+}
+
+// SubdivisionsOfType returns the subdivisions of any of the types t, sorted by
+// code.
+func (c *Country) SubdivisionsOfType(t ...SubdivisionType) []Subdivision {
+	return c.filterSubdivisions(func(s Subdivision) bool {
+		for _, typ := range t {
+			if s.Type == normalizeSubdivisionType(typ) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterSubdivisions returns the subdivisions for which keep returns true,
+// sorted by code.
+func (c *Country) filterSubdivisions(keep func(Subdivision) bool) []Subdivision {
+	result := make([]Subdivision, 0)
+	for _, s := range c.Subdivisions {
+		if keep(s) {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
@@ -0,0 +1,162 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubdivision(t *testing.T) {
+	c, s, err := countries.GetSubdivision("US-CA")
+	assert.Nil(t, err)
+	assert.Equal(t, "US", c.Alpha2)
+	assert.Equal(t, "California", s.Name)
+	assert.Equal(t, "US-CA", s.ISOCode())
+
+	c, s, err = countries.GetSubdivision("it-rm")
+	assert.Nil(t, err)
+	assert.Equal(t, "IT", c.Alpha2)
+	assert.Equal(t, "Roma", s.Name)
+
+	_, _, err = countries.GetSubdivision("US-XX")
+	assert.ErrorIs(t, err, countries.ErrSubdivisionNotFound)
+	_, _, err = countries.GetSubdivision("XX-CA")
+	assert.ErrorIs(t, err, countries.ErrSubdivisionNotFound)
+
+	for _, code := range []string{"", "US", "USCA", "USA-CA", "US-", "US-CALI", "U1-CA", "US-C A"} {
+		_, _, err = countries.GetSubdivision(code)
+		assert.ErrorIs(t, err, countries.ErrInvalidSubdivisionCode, code)
+	}
+}
+
+func TestSubdivisionISOCode(t *testing.T) {
+	assert.Equal(t, "", countries.Subdivision{}.ISOCode())
+	for _, c := range countries.Data.All {
+		for code, s := range c.Subdivisions {
+			assert.Equal(t, c.Alpha2, s.CountryAlpha2)
+			_, found, err := countries.GetSubdivision(c.Alpha2 + "-" + code)
+			if assert.Nil(t, err, c.Alpha2+"-"+code) {
+				assert.Equal(t, s.Name, found.Name)
+			}
+		}
+	}
+}
+
+func ExampleGetSubdivision() {
+	c, s, _ := countries.GetSubdivision("US-CA")
+	fmt.Println(c.ISOShortName)
+	fmt.Println(s.Name)
+	fmt.Println(s.ISOCode())
+	// Output:
+	// United States of America
+	// California
+	// US-CA
+}
+
+func TestSubdivisionHierarchy(t *testing.T) {
+	it := countries.Get("IT")
+	regions := it.TopLevelSubdivisions()
+	assert.Equal(t, 20, len(regions))
+	assert.Equal(t, "21", regions[0].Code)
+
+	provinces := it.SubdivisionChildren("34")
+	assert.Equal(t, 7, len(provinces))
+	assert.Equal(t, "BL", provinces[0].Code)
+	assert.Empty(t, it.SubdivisionChildren("RM"))
+	assert.Empty(t, it.SubdivisionChildren(""))
+
+	rm := it.Subdivision("RM")
+	assert.Equal(t, "62", rm.Parent)
+	ancestors := rm.Ancestors()
+	assert.Equal(t, 1, len(ancestors))
+	assert.Equal(t, "Lazio", ancestors[0].Name)
+	assert.Empty(t, it.Subdivision("62").Ancestors())
+
+	fr := countries.Get("FR")
+	ancestors = fr.Subdivision("67").Ancestors()
+	assert.Equal(t, 2, len(ancestors))
+	assert.Equal(t, "6AE", ancestors[0].Code)
+	assert.Equal(t, "GES", ancestors[1].Code)
+
+	gb := countries.Get("GB")
+	assert.Equal(t, "WLS", gb.Subdivision("CRF").Parent)
+	assert.Equal(t, 4, len(gb.TopLevelSubdivisions()))
+
+	us := countries.Get("US")
+	assert.Equal(t, len(us.Subdivisions), len(us.TopLevelSubdivisions()))
+}
+
+func TestSubdivisionParentsExist(t *testing.T) {
+	for _, c := range countries.Data.All {
+		for code, s := range c.Subdivisions {
+			if s.Parent == "" {
+				continue
+			}
+			_, found := c.Subdivisions[s.Parent]
+			assert.True(t, found, "%s-%s has unknown parent %s", c.Alpha2, code, s.Parent)
+			assert.NotEqual(t, code, s.Parent)
+		}
+	}
+}
+
+func ExampleCountry_SubdivisionChildren() {
+	c := countries.Get("BE")
+	for _, s := range c.SubdivisionChildren("WAL") {
+		fmt.Println(s.Code)
+	}
+	// Output:
+	// WBR
+	// WHT
+	// WLG
+	// WLX
+	// WNA
+}
+
+func TestSubdivisionsOfType(t *testing.T) {
+	us := countries.Get("US")
+	states := us.SubdivisionsOfType(countries.SubdivisionTypeState)
+	assert.Equal(t, 50, len(states))
+	assert.Equal(t, "AK", states[0].Code)
+	assert.Equal(t, 6, len(us.SubdivisionsOfType(countries.SubdivisionTypeOutlyingArea)))
+	assert.Equal(t, 51, len(us.SubdivisionsOfType(countries.SubdivisionTypeState, countries.SubdivisionTypeDistrict)))
+
+	it := countries.Get("IT")
+	regions := it.SubdivisionsOfType(countries.SubdivisionTypeRegion, countries.SubdivisionTypeAutonomousRegion)
+	assert.Equal(t, 20, len(regions))
+	assert.Empty(t, it.SubdivisionsOfType())
+}
+
+func TestSubdivisionTypeNormalization(t *testing.T) {
+	bj := countries.Get("BJ")
+	assert.Equal(t, len(bj.Subdivisions), len(bj.SubdivisionsOfType(countries.SubdivisionTypeDepartment)))
+	assert.Equal(t, 1, len(countries.Get("MX").SubdivisionsOfType(countries.SubdivisionTypeCapitalCity)))
+	assert.Equal(t, 1, len(countries.Get("MX").SubdivisionsOfType("Capital")))
+}
+
+func TestSubdivisionUnofficialNamesAndComments(t *testing.T) {
+	us := countries.Get("US")
+	assert.Equal(t, []string{"Alaska"}, us.Subdivision("AK").UnofficialNames)
+
+	it := countries.Get("IT")
+	assert.Equal(t, []string{"Piemonte"}, it.Subdivision("21").UnofficialNames)
+
+	no := countries.Get("NO")
+	assert.Equal(t, "See also country code SJ", no.Subdivision("22").Comments)
+
+	total := 0
+	for _, c := range countries.Data.All {
+		for _, s := range c.Subdivisions {
+			total += len(s.UnofficialNames)
+		}
+	}
+	assert.Greater(t, total, 4000)
+}
+
+func TestSubdivisionByNameAliases(t *testing.T) {
+	it := countries.Get("IT")
+	assert.Equal(t, "21", it.SubdivisionByName("Piemonte").Code)
+	assert.Equal(t, "21", it.SubdivisionByName("Piedmont").Code)
+	assert.Equal(t, "", it.SubdivisionByName("").Code)
+}
@@ -0,0 +1,135 @@
+package countries
+
+import "strings"
+
+// SubdivisionType is the category of a subdivision, like a state, a province
+// or a region.
+type SubdivisionType string
+
+// Subdivision types found in subdivisions data.
+const (
+	SubdivisionTypeAdministration                            SubdivisionType = "administration"
+	SubdivisionTypeAdministrativeAtoll                       SubdivisionType = "administrative_atoll"
+	SubdivisionTypeAdministrativePrecinct                    SubdivisionType = "administrative_precinct"
+	SubdivisionTypeAdministrativeRegion                      SubdivisionType = "administrative_region"
+	SubdivisionTypeAdministrativeTerritory                   SubdivisionType = "administrative_territory"
+	SubdivisionTypeArcticRegion                              SubdivisionType = "arctic_region"
+	SubdivisionTypeArea                                      SubdivisionType = "area"
+	SubdivisionTypeAutonomousCity                            SubdivisionType = "autonomous_city"
+	SubdivisionTypeAutonomousCityInNorthAfrica               SubdivisionType = "autonomous_city_in_north_africa"
+	SubdivisionTypeAutonomousCommunity                       SubdivisionType = "autonomous_community"
+	SubdivisionTypeAutonomousDistrict                        SubdivisionType = "autonomous_district"
+	SubdivisionTypeAutonomousMunicipality                    SubdivisionType = "autonomous_municipality"
+	SubdivisionTypeAutonomousProvince                        SubdivisionType = "autonomous_province"
+	SubdivisionTypeAutonomousRegion                          SubdivisionType = "autonomous_region"
+	SubdivisionTypeAutonomousRepublic                        SubdivisionType = "autonomous_republic"
+	SubdivisionTypeAutonomousSector                          SubdivisionType = "autonomous_sector"
+	SubdivisionTypeAutonomousTerritorialUnit                 SubdivisionType = "autonomous_territorial_unit"
+	SubdivisionTypeBorough                                   SubdivisionType = "borough"
+	SubdivisionTypeCanton                                    SubdivisionType = "canton"
+	SubdivisionTypeCapitalCity                               SubdivisionType = "capital_city"
+	SubdivisionTypeCapitalDistrict                           SubdivisionType = "capital_district"
+	SubdivisionTypeCapitalRegion                             SubdivisionType = "capital_region"
+	SubdivisionTypeCapitalTerritory                          SubdivisionType = "capital_territory"
+	SubdivisionTypeChainOfIslands                            SubdivisionType = "chain_of_islands"
+	SubdivisionTypeCity                                      SubdivisionType = "city"
+	SubdivisionTypeCityCorporation                           SubdivisionType = "city_corporation"
+	SubdivisionTypeCityMunicipality                          SubdivisionType = "city_municipality"
+	SubdivisionTypeCityWithCountyRights                      SubdivisionType = "city_with_county_rights"
+	SubdivisionTypeCommune                                   SubdivisionType = "commune"
+	SubdivisionTypeCouncilArea                               SubdivisionType = "council_area"
+	SubdivisionTypeCountry                                   SubdivisionType = "country"
+	SubdivisionTypeCounty                                    SubdivisionType = "county"
+	SubdivisionTypeDecentralizedRegionalEntity               SubdivisionType = "decentralized_regional_entity"
+	SubdivisionTypeDepartment                                SubdivisionType = "department"
+	SubdivisionTypeDependency                                SubdivisionType = "dependency"
+	SubdivisionTypeDevelopmentRegion                         SubdivisionType = "development_region"
+	SubdivisionTypeDistrict                                  SubdivisionType = "district"
+	SubdivisionTypeDistrictMunicipality                      SubdivisionType = "district_municipality"
+	SubdivisionTypeDistrictWithSpecialStatus                 SubdivisionType = "district_with_special_status"
+	SubdivisionTypeDistrictsUnderRepublicAdministration      SubdivisionType = "districts_under_republic_administration"
+	SubdivisionTypeDivision                                  SubdivisionType = "division"
+	SubdivisionTypeEconomicPrefecture                        SubdivisionType = "economic_prefecture"
+	SubdivisionTypeEmirate                                   SubdivisionType = "emirate"
+	SubdivisionTypeEntity                                    SubdivisionType = "entity"
+	SubdivisionTypeEuropeanCollectivity                      SubdivisionType = "european_collectivity"
+	SubdivisionTypeFederalCapitalTerritory                   SubdivisionType = "federal_capital_territory"
+	SubdivisionTypeFederalDependency                         SubdivisionType = "federal_dependency"
+	SubdivisionTypeFederalDistrict                           SubdivisionType = "federal_district"
+	SubdivisionTypeFederalTerritory                          SubdivisionType = "federal_territory"
+	SubdivisionTypeFreeMunicipalConsortium                   SubdivisionType = "free_municipal_consortium"
+	SubdivisionTypeGeographicalEntity                        SubdivisionType = "geographical_entity"
+	SubdivisionTypeGeographicalRegion                        SubdivisionType = "geographical_region"
+	SubdivisionTypeGeographicalUnit                          SubdivisionType = "geographical_unit"
+	SubdivisionTypeGovernorate                               SubdivisionType = "governorate"
+	SubdivisionTypeGroupOfIslands                            SubdivisionType = "group_of_islands"
+	SubdivisionTypeIndigenousRegion                          SubdivisionType = "indigenous_region"
+	SubdivisionTypeIsland                                    SubdivisionType = "island"
+	SubdivisionTypeIslandCouncil                             SubdivisionType = "island_council"
+	SubdivisionTypeLocalCouncil                              SubdivisionType = "local_council"
+	SubdivisionTypeLondonBorough                             SubdivisionType = "london_borough"
+	SubdivisionTypeMetropolitanAdministration                SubdivisionType = "metropolitan_administration"
+	SubdivisionTypeMetropolitanCity                          SubdivisionType = "metropolitan_city"
+	SubdivisionTypeMetropolitanCollectivityWithSpecialStatus SubdivisionType = "metropolitan_collectivity_with_special_status"
+	SubdivisionTypeMetropolitanDepartment                    SubdivisionType = "metropolitan_department"
+	SubdivisionTypeMetropolitanDistrict                      SubdivisionType = "metropolitan_district"
+	SubdivisionTypeMetropolitanRegion                        SubdivisionType = "metropolitan_region"
+	SubdivisionTypeMunicipality                              SubdivisionType = "municipality"
+	SubdivisionTypeOblast                                    SubdivisionType = "oblast"
+	SubdivisionTypeOutlyingArea                              SubdivisionType = "outlying_area"
+	SubdivisionTypeOverseasCollectivity                      SubdivisionType = "overseas_collectivity"
+	SubdivisionTypeOverseasDepartmentalCollectivity          SubdivisionType = "overseas_departmental_collectivity"
+	SubdivisionTypeOverseasUniqueTerritorialCollectivity     SubdivisionType = "overseas_unique_territorial_collectivity"
+	SubdivisionTypePakistanAdministeredArea                  SubdivisionType = "pakistan_administered_area"
+	SubdivisionTypeParish                                    SubdivisionType = "parish"
+	SubdivisionTypePopularate                                SubdivisionType = "popularate"
+	SubdivisionTypePrefecture                                SubdivisionType = "prefecture"
+	SubdivisionTypeProvince                                  SubdivisionType = "province"
+	SubdivisionTypeQuarter                                   SubdivisionType = "quarter"
+	SubdivisionTypeRayon                                     SubdivisionType = "rayon"
+	SubdivisionTypeRegion                                    SubdivisionType = "region"
+	SubdivisionTypeRegionalState                             SubdivisionType = "regional_state"
+	SubdivisionTypeRepublic                                  SubdivisionType = "republic"
+	SubdivisionTypeRuralMunicipality                         SubdivisionType = "rural_municipality"
+	SubdivisionTypeSelfGovernedPart                          SubdivisionType = "self-governed_part"
+	SubdivisionTypeSpecialAdministrativeCity                 SubdivisionType = "special_administrative_city"
+	SubdivisionTypeSpecialAdministrativeRegion               SubdivisionType = "special_administrative_region"
+	SubdivisionTypeSpecialCity                               SubdivisionType = "special_city"
+	SubdivisionTypeSpecialIslandAuthority                    SubdivisionType = "special_island_authority"
+	SubdivisionTypeSpecialMunicipality                       SubdivisionType = "special_municipality"
+	SubdivisionTypeSpecialRegion                             SubdivisionType = "special_region"
+	SubdivisionTypeSpecialSelfGoverningCity                  SubdivisionType = "special_self-governing_city"
+	SubdivisionTypeSpecialSelfGoverningProvince              SubdivisionType = "special_self-governing_province"
+	SubdivisionTypeState                                     SubdivisionType = "state"
+	SubdivisionTypeStateCity                                 SubdivisionType = "state_city"
+	SubdivisionTypeTerritorialUnit                           SubdivisionType = "territorial_unit"
+	SubdivisionTypeTerritory                                 SubdivisionType = "territory"
+	SubdivisionTypeTown                                      SubdivisionType = "town"
+	SubdivisionTypeTownCouncil                               SubdivisionType = "town_council"
+	SubdivisionTypeTwoTierCounty                             SubdivisionType = "two-tier_county"
+	SubdivisionTypeUnionTerritory                            SubdivisionType = "union_territory"
+	SubdivisionTypeUnitaryAuthority                          SubdivisionType = "unitary_authority"
+	SubdivisionTypeUrbanCommunity                            SubdivisionType = "urban_community"
+	SubdivisionTypeUrbanMunicipality                         SubdivisionType = "urban_municipality"
+	SubdivisionTypeVoivodeship                               SubdivisionType = "voivodeship"
+	SubdivisionTypeWard                                      SubdivisionType = "ward"
+	SubdivisionTypeZone                                      SubdivisionType = "zone"
+)
+
+// subdivisionTypeAliases maps spelling variants found in subdivisions data to
+// their canonical subdivision type.
+var subdivisionTypeAliases = map[string]SubdivisionType{
+	"capital":     SubdivisionTypeCapitalCity,
+	"departments": SubdivisionTypeDepartment,
+}
+
+// normalizeSubdivisionType returns the canonical subdivision type of t: lower
+// cased, with spaces replaced by underscores and spelling variants merged.
+func normalizeSubdivisionType(t SubdivisionType) SubdivisionType {
+	s := strings.ToLower(strings.TrimSpace(string(t)))
+	s = strings.Join(strings.Fields(s), "_")
+	if alias, found := subdivisionTypeAliases[s]; found {
+		return alias
+	}
+	return SubdivisionType(s)
+}
@@ -0,0 +1,159 @@
+package countries
+
+import (
+	"math"
+	"math/bits"
+)
+
+// VATCategory is a category of goods and services that can be taxed with a
+// VAT rate other than the standard one.
+type VATCategory string
+
+// VAT categories.
+const (
+	// VATCategoryStandard is any good or service taxed with the standard rate.
+	VATCategoryStandard VATCategory = "standard"
+	// VATCategoryBooks are printed books.
+	VATCategoryBooks VATCategory = "books"
+	// VATCategoryFood are basic foodstuffs, like bread, milk, fruit and
+	// vegetables, excluding alcoholic beverages and restaurant services.
+	VATCategoryFood VATCategory = "food"
+	// VATCategoryEServices are electronically supplied services, like
+	// software, streaming and web hosting.
+	VATCategoryEServices VATCategory = "e_services"
+	// VATCategoryPassengerTransport is the domestic transport of passengers.
+	VATCategoryPassengerTransport VATCategory = "passenger_transport"
+)
+
+// vatCategoryRates are the VAT rates of the categories taxed with a rate other
+// than the standard one, by country. The rates are among the country VatRates,
+// or zero for zero rated supplies.
+var vatCategoryRates = map[string]map[VATCategory]float64{
+	"AT": {VATCategoryBooks: 10, VATCategoryFood: 10, VATCategoryPassengerTransport: 10},
+	"BE": {VATCategoryBooks: 6, VATCategoryFood: 6, VATCategoryPassengerTransport: 6},
+	"BG": {VATCategoryBooks: 9},
+	"CH": {VATCategoryBooks: 2.6, VATCategoryFood: 2.6},
+	"CY": {VATCategoryBooks: 5, VATCategoryFood: 5, VATCategoryPassengerTransport: 9},
+	"CZ": {VATCategoryFood: 15, VATCategoryPassengerTransport: 15},
+	"DE": {VATCategoryBooks: 7, VATCategoryFood: 7, VATCategoryPassengerTransport: 7},
+	"EE": {VATCategoryBooks: 9},
+	"ES": {VATCategoryBooks: 4, VATCategoryFood: 4, VATCategoryPassengerTransport: 10},
+	"FI": {VATCategoryBooks: 10, VATCategoryFood: 14, VATCategoryPassengerTransport: 10},
+	"FR": {VATCategoryBooks: 5.5, VATCategoryFood: 5.5, VATCategoryPassengerTransport: 10},
+	"GB": {VATCategoryBooks: 0, VATCategoryFood: 0, VATCategoryPassengerTransport: 0},
+	"GR": {VATCategoryBooks: 6, VATCategoryFood: 13, VATCategoryPassengerTransport: 13},
+	"HR": {VATCategoryBooks: 5, VATCategoryFood: 5},
+	"HU": {VATCategoryBooks: 5, VATCategoryFood: 5},
+	"IE": {VATCategoryBooks: 0, VATCategoryFood: 0, VATCategoryPassengerTransport: 0},
+	"IT": {VATCategoryBooks: 4, VATCategoryFood: 4, VATCategoryPassengerTransport: 10},
+	"LT": {VATCategoryBooks: 9, VATCategoryPassengerTransport: 9},
+	"LU": {VATCategoryBooks: 3, VATCategoryFood: 3, VATCategoryPassengerTransport: 3},
+	"LV": {VATCategoryBooks: 12, VATCategoryPassengerTransport: 12},
+	"MT": {VATCategoryBooks: 5, VATCategoryFood: 0, VATCategoryPassengerTransport: 0},
+	"NL": {VATCategoryBooks: 9, VATCategoryFood: 9, VATCategoryPassengerTransport: 9},
+	"NO": {VATCategoryBooks: 0},
+	"PL": {VATCategoryBooks: 5, VATCategoryFood: 5, VATCategoryPassengerTransport: 8},
+	"PT": {VATCategoryBooks: 6, VATCategoryFood: 6, VATCategoryPassengerTransport: 6},
+	"RO": {VATCategoryBooks: 5, VATCategoryFood: 9},
+	"SE": {VATCategoryBooks: 6, VATCategoryFood: 12, VATCategoryPassengerTransport: 6},
+	"SI": {VATCategoryBooks: 9.5, VATCategoryFood: 9.5, VATCategoryPassengerTransport: 9.5},
+	"SK": {VATCategoryBooks: 10, VATCategoryFood: 10},
+}
+
+// VAT is the VAT rate, as a percentage, of a category of goods and services in
+// a country.
+type VAT struct {
+	Country  *Country
+	Category VATCategory
+	Rate     float64
+}
+
+// VATAmount is an amount of money split into the net amount and the VAT, in
+// minor units of currency.
+type VATAmount struct {
+	Net   int64
+	VAT   int64
+	Gross int64
+}
+
+// VAT returns the VAT rate of category in the country. Categories without a
+// specific rate in the country, like VATCategoryEServices in the European
+// Union, are taxed with the standard rate. If the country has no VAT the rate
+// is zero.
+func (c *Country) VAT(category VATCategory) VAT {
+	rate := c.VatRates.Standard
+	if r, found := vatCategoryRates[c.Alpha2][category]; found {
+		rate = r
+	}
+	return VAT{Country: c, Category: category, Rate: rate}
+}
+
+// Apply adds the VAT to netAmount, expressed in minor units of currency (like
+// cents). The VAT is rounded to the nearest minor unit, halves away from zero.
+// Amounts that do not fit in an int64, like the gross amount of a netAmount
+// greater than math.MaxInt64 / (1 + Rate/100), about 7.2e18 for a 27% rate,
+// are saturated to math.MaxInt64 or math.MinInt64 instead of overflowing.
+func (v VAT) Apply(netAmount int64) VATAmount {
+	tax := mulDivRound(netAmount, v.basisPoints(), 10000)
+	gross := netAmount + tax
+	if tax > 0 && gross < netAmount {
+		gross = math.MaxInt64
+	} else if tax < 0 && gross > netAmount {
+		gross = math.MinInt64
+	}
+	return VATAmount{Net: netAmount, VAT: tax, Gross: gross}
+}
+
+// ExtractFromGross splits gross, a price including the VAT expressed in minor
+// units of currency, into the net amount and the VAT. The VAT is rounded to the
+// nearest minor unit, halves away from zero, and the net amount is the rest, so
+// that they always add up to gross.
+func (v VAT) ExtractFromGross(gross int64) VATAmount {
+	bp := v.basisPoints()
+	tax := mulDivRound(gross, bp, 10000+bp)
+	return VATAmount{Net: gross - tax, VAT: tax, Gross: gross}
+}
+
+// basisPoints returns the rate in hundredths of a percent, like 550 for 5.5%.
+// Negative and NaN rates are zero and huge rates are saturated to
+// math.MaxInt64.
+func (v VAT) basisPoints() uint64 {
+	if !(v.Rate > 0) {
+		return 0
+	}
+	bp := math.Round(v.Rate * 100)
+	if bp >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return uint64(bp)
+}
+
+// mulDivRound returns amount * n / d rounded to the nearest integer, halves
+// away from zero, without overflowing in the multiplication. A result that does
+// not fit in an int64 is saturated to math.MaxInt64 or math.MinInt64.
+func mulDivRound(amount int64, n, d uint64) int64 {
+	abs := uint64(amount)
+	if amount < 0 {
+		abs = -abs
+	}
+	hi, lo := bits.Mul64(abs, n)
+	// bits.Div64 panics if the quotient does not fit in 64 bits.
+	q := uint64(math.MaxUint64)
+	if hi < d {
+		var r uint64
+		q, r = bits.Div64(hi, lo, d)
+		if r >= d-r && q < math.MaxUint64 {
+			q++
+		}
+	}
+	if amount < 0 {
+		if q >= 1<<63 {
+			return math.MinInt64
+		}
+		return -int64(q)
+	}
+	if q > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(q)
+}
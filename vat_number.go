@@ -0,0 +1,446 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidVATNumber is returned when a VAT number does not have the
+	// format used in its country.
+	ErrInvalidVATNumber = errors.New("invalid VAT number")
+	// ErrUnknownVATPrefix is returned when a VAT number does not start with
+	// the prefix of a country of the European Union VAT area.
+	ErrUnknownVATPrefix = errors.New("unknown VAT number prefix")
+	// ErrVATNumberChecksum is returned when the check digits of a VAT number
+	// do not match.
+	ErrVATNumberChecksum = errors.New("invalid VAT number checksum")
+)
+
+// VATNumber is a VAT identification number split into the prefix, like "IT",
+// "EL" or "XI", and the national number, without spaces and punctuation.
+type VATNumber struct {
+	Country *Country
+	Prefix  string
+	Number  string
+}
+
+// String returns the VAT number in its compact form, like "IT00743110157".
+func (v VATNumber) String() string {
+	return v.Prefix + v.Number
+}
+
+// vatNumberRule is the format of the VAT numbers of a country and the
+// algorithm that checks their check digits.
+type vatNumberRule struct {
+	format   *regexp.Regexp
+	checksum func(number string) bool
+}
+
+// vatNumberRules are the VAT number rules by prefix (see vatPrefixCountry).
+// Monaco, in the European Union VAT area, has no rule: its businesses have FR
+// VAT numbers.
+var vatNumberRules = map[string]vatNumberRule{
+	"AT": {regexp.MustCompile(`^U\d{8}$`), checkVATNumberAT},
+	"BE": {regexp.MustCompile(`^[01]\d{9}$`), checkVATNumberBE},
+	"BG": {regexp.MustCompile(`^\d{9,10}$`), checkVATNumberBG},
+	"CY": {regexp.MustCompile(`^[0-59]\d{7}[A-Z]$`), checkVATNumberCY},
+	"CZ": {regexp.MustCompile(`^\d{8,10}$`), checkVATNumberCZ},
+	"DE": {regexp.MustCompile(`^[1-9]\d{8}$`), checkMod11_10},
+	"DK": {regexp.MustCompile(`^[1-9]\d{7}$`), checkVATNumberDK},
+	"EE": {regexp.MustCompile(`^10\d{7}$`), checkVATNumberEE},
+	"EL": {regexp.MustCompile(`^\d{8,9}$`), checkVATNumberEL},
+	"ES": {regexp.MustCompile(`^[0-9A-Z]\d{7}[0-9A-Z]$`), checkVATNumberES},
+	"FI": {regexp.MustCompile(`^\d{8}$`), checkVATNumberFI},
+	"FR": {regexp.MustCompile(`^[0-9A-HJ-NP-Z]{2}\d{9}$`), checkVATNumberFR},
+	"HR": {regexp.MustCompile(`^\d{11}$`), checkMod11_10},
+	"HU": {regexp.MustCompile(`^\d{8}$`), checkVATNumberHU},
+	"IE": {regexp.MustCompile(`^(?:\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`), checkVATNumberIE},
+	"IT": {regexp.MustCompile(`^\d{11}$`), checkVATNumberIT},
+	"LT": {regexp.MustCompile(`^(?:\d{7}1\d|\d{10}1\d)$`), checkVATNumberLT},
+	"LU": {regexp.MustCompile(`^\d{8}$`), checkVATNumberLU},
+	"LV": {regexp.MustCompile(`^\d{11}$`), checkVATNumberLV},
+	"MT": {regexp.MustCompile(`^[1-9]\d{7}$`), checkVATNumberMT},
+	"NL": {regexp.MustCompile(`^\d{9}B\d{2}$`), checkVATNumberNL},
+	"PL": {regexp.MustCompile(`^\d{10}$`), checkVATNumberPL},
+	"PT": {regexp.MustCompile(`^[1-9]\d{8}$`), checkVATNumberPT},
+	"RO": {regexp.MustCompile(`^[1-9]\d{1,9}$`), checkVATNumberRO},
+	"SE": {regexp.MustCompile(`^\d{10}01$`), checkVATNumberSE},
+	"SI": {regexp.MustCompile(`^[1-9]\d{7}$`), checkVATNumberSI},
+	"SK": {regexp.MustCompile(`^[1-9]\d[2-47-9]\d{7}$`), checkVATNumberSK},
+	"XI": {regexp.MustCompile(`^(?:\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`), checkVATNumberGB},
+}
+
+// vatPrefixCountries are the countries of the VAT number prefixes that are
+// not the country alpha2 code.
+var vatPrefixCountries = map[string]string{
+	"EL": "GR",
+	"XI": "GB",
+}
+
+// vatPrefixCountry returns the country of the VAT number prefix, that is the
+// alpha2 code of a country of the European Union VAT area (see
+// Country.EUVATMember) or one of vatPrefixCountries. If prefix is not a VAT
+// number prefix, like "GR" for Greece that uses EL, returns nil.
+func vatPrefixCountry(prefix string) *Country {
+	if alpha2, found := vatPrefixCountries[prefix]; found {
+		return Get(alpha2)
+	}
+	for _, alpha2 := range vatPrefixCountries {
+		if alpha2 == prefix {
+			return nil
+		}
+	}
+	if c := Get(prefix); c != nil && c.EUVATMember() {
+		return c
+	}
+	return nil
+}
+
+// ValidateVATNumber parses and validates a European Union VAT identification
+// number, like "IT 00743110157" or "EL094259216". Spaces, dashes, dots and
+// slashes are ignored and letters are case insensitive. The prefix is the
+// alpha2 code of a country of the European Union VAT area (see
+// Country.EUVATMember), except for Greece that uses EL, or XI for Northern
+// Ireland, and the number must have the format and the check digits used in
+// the country. Some numbers have no public check digits algorithm and only
+// their format is checked: the French numbers with letters in the two
+// character key, like "FRK7399859412", and the numbers of the government
+// departments (GD) and health authorities (HA) of Northern Ireland. It returns
+// ErrUnknownVATPrefix, ErrInvalidVATNumber or ErrVATNumberChecksum if the VAT
+// number is not valid. Whether the VAT number is actually assigned can only be
+// verified with the VIES service.
+func ValidateVATNumber(vat string) (VATNumber, error) {
+	compact := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '/', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(vat)))
+	if len(compact) < 2 {
+		return VATNumber{}, fmt.Errorf("%w: %q", ErrInvalidVATNumber, vat)
+	}
+	prefix, number := compact[:2], compact[2:]
+	country := vatPrefixCountry(prefix)
+	rule, found := vatNumberRules[prefix]
+	if country == nil || !found {
+		return VATNumber{}, fmt.Errorf("%w: %q", ErrUnknownVATPrefix, prefix)
+	}
+	if !rule.format.MatchString(number) {
+		return VATNumber{}, fmt.Errorf("%w: %q does not match the format of %s", ErrInvalidVATNumber, number, prefix)
+	}
+	if !rule.checksum(number) {
+		return VATNumber{}, fmt.Errorf("%w: %s%s", ErrVATNumberChecksum, prefix, number)
+	}
+	return VATNumber{Country: country, Prefix: prefix, Number: number}, nil
+}
+
+// digitAt returns the value of the digit at index i of number.
+func digitAt(number string, i int) int {
+	return int(number[i] - '0')
+}
+
+// weightedSum returns the sum of the digits of number multiplied by weights.
+// Digits without a weight are ignored.
+func weightedSum(number string, weights ...int) int {
+	sum := 0
+	for i, w := range weights {
+		if i < len(number) {
+			sum += w * digitAt(number, i)
+		}
+	}
+	return sum
+}
+
+// luhnChecksum returns the Luhn checksum of number, that is zero if number
+// ends with a valid Luhn check digit.
+func luhnChecksum(number string) int {
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		d := digitAt(number, len(number)-1-i)
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum % 10
+}
+
+// mod97 returns the remainder of the division by 97 of the integer written in
+// number.
+func mod97(number string) int {
+	r := 0
+	for i := 0; i < len(number); i++ {
+		r = (r*10 + digitAt(number, i)) % 97
+	}
+	return r
+}
+
+// checkMod11_10 checks the ISO 7064 MOD 11,10 check digit, used in DE and HR.
+func checkMod11_10(number string) bool {
+	product := 10
+	for i := 0; i < len(number)-1; i++ {
+		sum := (digitAt(number, i) + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = sum * 2 % 11
+	}
+	return (11-product)%10 == digitAt(number, len(number)-1)
+}
+
+func checkVATNumberAT(number string) bool {
+	return (16-luhnChecksum(number[1:8]))%10 == digitAt(number, 8)
+}
+
+func checkVATNumberBE(number string) bool {
+	n, _ := strconv.Atoi(number[:8])
+	check, _ := strconv.Atoi(number[8:])
+	return 97-n%97 == check
+}
+
+func checkVATNumberBG(number string) bool {
+	if len(number) == 9 {
+		check := weightedSum(number, 1, 2, 3, 4, 5, 6, 7, 8) % 11
+		if check == 10 {
+			check = weightedSum(number, 3, 4, 5, 6, 7, 8, 9, 10) % 11 % 10
+		}
+		return check == digitAt(number, 8)
+	}
+	last := digitAt(number, 9)
+	// Personal number of Bulgarian citizens (EGN), of foreigners (PNF) and
+	// other numbers.
+	if weightedSum(number, 2, 4, 8, 5, 10, 9, 7, 3, 6)%11%10 == last {
+		return true
+	}
+	if weightedSum(number, 21, 19, 17, 13, 11, 9, 7, 3, 1)%10 == last {
+		return true
+	}
+	check := 11 - weightedSum(number, 4, 3, 2, 7, 6, 5, 4, 3, 2)%11
+	return check != 10 && check%11 == last
+}
+
+func checkVATNumberCY(number string) bool {
+	if strings.HasPrefix(number, "12") {
+		return false
+	}
+	even := [10]int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		if i%2 == 0 {
+			sum += even[digitAt(number, i)]
+		} else {
+			sum += digitAt(number, i)
+		}
+	}
+	return number[8] == byte('A'+sum%26)
+}
+
+func checkVATNumberCZ(number string) bool {
+	switch {
+	case len(number) == 8:
+		// Legal entities.
+		if number[0] == '9' {
+			return false
+		}
+		check := (11 - weightedSum(number, 8, 7, 6, 5, 4, 3, 2)%11) % 11
+		if check == 0 {
+			check = 1
+		}
+		return check%10 == digitAt(number, 7)
+	case len(number) == 9 && number[0] == '6':
+		// Individuals without a birth number.
+		check := weightedSum(number[1:], 8, 7, 6, 5, 4, 3, 2) % 11
+		return (8-(10-check)%11)%10 == digitAt(number, 8)
+	case len(number) == 9:
+		// Birth numbers issued before 1954 have no check digit.
+		year, _ := strconv.Atoi(number[:2])
+		return year < 54
+	default:
+		// Birth numbers.
+		n, _ := strconv.ParseInt(number[:9], 10, 64)
+		return n%11%10 == int64(digitAt(number, 9))
+	}
+}
+
+func checkVATNumberDK(number string) bool {
+	return weightedSum(number, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+func checkVATNumberEE(number string) bool {
+	return (10-weightedSum(number, 3, 7, 1, 3, 7, 1, 3, 7)%10)%10 == digitAt(number, 8)
+}
+
+func checkVATNumberEL(number string) bool {
+	// Old numbers have eight digits.
+	number = strings.Repeat("0", 9-len(number)) + number
+	return weightedSum(number, 256, 128, 64, 32, 16, 8, 4, 2)%11%10 == digitAt(number, 8)
+}
+
+func checkVATNumberES(number string) bool {
+	const nifLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+	first, last := number[0], number[8]
+	switch {
+	case first >= '0' && first <= '9':
+		// Spanish citizens (DNI).
+		n, _ := strconv.Atoi(number[:8])
+		return last == nifLetters[n%23]
+	case first == 'X' || first == 'Y' || first == 'Z':
+		// Foreigners (NIE).
+		n, _ := strconv.Atoi(string('0'+first-'X') + number[1:8])
+		return last == nifLetters[n%23]
+	case strings.IndexByte("ABCDEFGHJKLMNPQRSUVW", first) >= 0:
+		// Legal entities (CIF) and special individuals: the check character
+		// is a digit for companies, a letter for public bodies, non resident
+		// entities and special individuals, and either for the others.
+		check := (10 - luhnChecksum(number[1:8]+"0")) % 10
+		digit, letter := byte('0'+check), "JABCDEFGHI"[check]
+		switch {
+		case strings.IndexByte("ABEH", first) >= 0:
+			return last == digit
+		case strings.IndexByte("KLMNPQRSW", first) >= 0:
+			return last == letter
+		}
+		return last == digit || last == letter
+	}
+	return false
+}
+
+func checkVATNumberFI(number string) bool {
+	return weightedSum(number, 7, 9, 10, 5, 8, 4, 2, 1)%11 == 0
+}
+
+func checkVATNumberFR(number string) bool {
+	if !isDigits(number[:2]) {
+		// The check characters of the new format have no public algorithm.
+		return true
+	}
+	key, _ := strconv.Atoi(number[:2])
+	return (12+3*mod97(number[2:]))%97 == key
+}
+
+func checkVATNumberGB(number string) bool {
+	if !isDigits(number) {
+		// Government departments and health authorities have no check digits.
+		return true
+	}
+	check := weightedSum(number, 8, 7, 6, 5, 4, 3, 2, 10, 1) % 97
+	// Numbers issued since 2010 add 55 to the checksum.
+	return check == 0 || check == 42
+}
+
+func checkVATNumberHU(number string) bool {
+	return weightedSum(number, 9, 7, 3, 1, 9, 7, 3, 1)%10 == 0
+}
+
+func checkVATNumberIE(number string) bool {
+	const letters = "WABCDEFGHIJKLMNOPQRSTUV"
+	if !isDigits(number[1:2]) {
+		// Old format, like "8D79739I".
+		number = "0" + number[2:7] + number[:1] + number[7:]
+	}
+	sum := weightedSum(number, 8, 7, 6, 5, 4, 3, 2)
+	if len(number) == 9 {
+		sum += 9 * strings.IndexByte(letters, number[8])
+	}
+	return number[7] == letters[sum%23]
+}
+
+func checkVATNumberIT(number string) bool {
+	if number[:7] == "0000000" {
+		return false
+	}
+	// The digits 8 to 10 are the code of the tax office.
+	office, _ := strconv.Atoi(number[7:10])
+	if (office < 1 || office > 100) && office != 120 && office != 121 && office != 888 && office != 999 {
+		return false
+	}
+	return luhnChecksum(number) == 0
+}
+
+func checkVATNumberLT(number string) bool {
+	n := len(number) - 1
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += (1 + i%9) * digitAt(number, i)
+	}
+	check := sum % 11
+	if check == 10 {
+		sum = 0
+		for i := 0; i < n; i++ {
+			sum += (1 + (i+2)%9) * digitAt(number, i)
+		}
+		check = sum % 11 % 10
+	}
+	return check == digitAt(number, n)
+}
+
+func checkVATNumberLU(number string) bool {
+	n, _ := strconv.Atoi(number[:6])
+	check, _ := strconv.Atoi(number[6:])
+	return n%89 == check
+}
+
+func checkVATNumberLV(number string) bool {
+	switch {
+	case number[0] > '3':
+		// Legal entities.
+		return weightedSum(number, 9, 1, 4, 8, 3, 10, 2, 5, 7, 6, 1)%11 == 3
+	case strings.HasPrefix(number, "32"):
+		// Personal codes issued since 2017 have no check digit.
+		return true
+	default:
+		// Personal codes with the birth date.
+		return (1+weightedSum(number, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9))%11%10 == digitAt(number, 10)
+	}
+}
+
+func checkVATNumberMT(number string) bool {
+	return weightedSum(number, 3, 4, 6, 7, 8, 9, 10, 1)%37 == 0
+}
+
+func checkVATNumberNL(number string) bool {
+	if number[10:] == "00" {
+		return false
+	}
+	// Numbers issued since 2020 to sole proprietors are checked with ISO 7064
+	// MOD 97-10 on the whole number, letters included (N=23, L=21, B=11).
+	if weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2, -1)%11 == 0 {
+		return true
+	}
+	return mod97("2321"+number[:9]+"11"+number[10:]) == 1
+}
+
+func checkVATNumberPL(number string) bool {
+	return weightedSum(number, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == digitAt(number, 9)
+}
+
+func checkVATNumberPT(number string) bool {
+	return (11-weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2)%11)%11%10 == digitAt(number, 8)
+}
+
+func checkVATNumberRO(number string) bool {
+	padded := strings.Repeat("0", 10-len(number)) + number
+	return weightedSum(padded, 7, 5, 3, 2, 1, 7, 5, 3, 2)*10%11%10 == digitAt(padded, 9)
+}
+
+func checkVATNumberSE(number string) bool {
+	return luhnChecksum(number[:10]) == 0
+}
+
+func checkVATNumberSI(number string) bool {
+	r := weightedSum(number, 8, 7, 6, 5, 4, 3, 2) % 11
+	return r != 0 && (11-r)%10 == digitAt(number, 7)
+}
+
+func checkVATNumberSK(number string) bool {
+	n, _ := strconv.ParseInt(number, 10, 64)
+	return n%11 == 0
+}
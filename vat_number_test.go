@@ -0,0 +1,112 @@
+package countries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVATNumber(t *testing.T) {
+	tests := []struct {
+		vat     string
+		country string
+		prefix  string
+		number  string
+	}{
+		{"ATU13585627", "AT", "AT", "U13585627"},
+		{"BE 0403.019.261", "BE", "BE", "0403019261"},
+		{"BG 175 074 752", "BG", "BG", "175074752"},
+		{"CY-10259033P", "CY", "CY", "10259033P"},
+		{"CZ 25123891", "CZ", "CZ", "25123891"},
+		{"DE 136 695 976", "DE", "DE", "136695976"},
+		{"DK 13585628", "DK", "DK", "13585628"},
+		{"EE 100 931 558", "EE", "EE", "100931558"},
+		{"EL 094259216", "GR", "EL", "094259216"},
+		{"EL 94259216", "GR", "EL", "94259216"},
+		{"ES A13 585 625", "ES", "ES", "A13585625"},
+		{"ES 54362315K", "ES", "ES", "54362315K"},
+		{"ES X2482300W", "ES", "ES", "X2482300W"},
+		{"ES Q2826000H", "ES", "ES", "Q2826000H"},
+		{"ES B58378431", "ES", "ES", "B58378431"},
+		{"FI 20774740", "FI", "FI", "20774740"},
+		{"fr 40 303 265 045", "FR", "FR", "40303265045"},
+		{"FR K7399859412", "FR", "FR", "K7399859412"},
+		{"HR 33392005961", "HR", "HR", "33392005961"},
+		{"HU-12892312", "HU", "HU", "12892312"},
+		{"IE 6433435F", "IE", "IE", "6433435F"},
+		{"IE 6433435OA", "IE", "IE", "6433435OA"},
+		{"IE 8D79739I", "IE", "IE", "8D79739I"},
+		{"IT 00743110157", "IT", "IT", "00743110157"},
+		{"LT 119511515", "LT", "LT", "119511515"},
+		{"LT 100001919017", "LT", "LT", "100001919017"},
+		{"LU 150 274 42", "LU", "LU", "15027442"},
+		{"LV 4000 3521 600", "LV", "LV", "40003521600"},
+		{"MT 1167-9112", "MT", "MT", "11679112"},
+		{"NL 004495445B01", "NL", "NL", "004495445B01"},
+		{"NL 002455799B11", "NL", "NL", "002455799B11"},
+		{"PL 856-734-62-15", "PL", "PL", "8567346215"},
+		{"PT 501 964 843", "PT", "PT", "501964843"},
+		{"RO 185 472 90", "RO", "RO", "18547290"},
+		{"SE 123456789701", "SE", "SE", "123456789701"},
+		{"SI 5022 3054", "SI", "SI", "50223054"},
+		{"SK 202 274 96 19", "SK", "SK", "2022749619"},
+		{"XI 980 7806 84", "GB", "XI", "980780684"},
+		{"XI GD123", "GB", "XI", "GD123"},
+	}
+	for _, tt := range tests {
+		v, err := countries.ValidateVATNumber(tt.vat)
+		if assert.NoError(t, err, tt.vat) {
+			assert.Equal(t, tt.country, v.Country.Alpha2, tt.vat)
+			assert.Equal(t, tt.prefix, v.Prefix, tt.vat)
+			assert.Equal(t, tt.number, v.Number, tt.vat)
+		}
+	}
+
+	for _, vat := range []string{"ATU13585626", "BE0403019262", "DE136695977", "EL094259217", "ESA13585626", "ES54362315T", "ESQ28260008", "ESB5837843A", "FR41303265045", "IE6433435G", "IT00743110158", "NL004495446B01", "PL8567346216", "XI980780685"} {
+		_, err := countries.ValidateVATNumber(vat)
+		assert.ErrorIs(t, err, countries.ErrVATNumberChecksum, vat)
+	}
+	for _, vat := range []string{"", "I", "IT0074311015", "ATU1358562", "DE036695976", "NL004495445", "EL0942592161"} {
+		_, err := countries.ValidateVATNumber(vat)
+		assert.ErrorIs(t, err, countries.ErrInvalidVATNumber, vat)
+	}
+	for _, vat := range []string{"GR094259216", "GB980780684", "CH123456789", "US123456789"} {
+		_, err := countries.ValidateVATNumber(vat)
+		assert.ErrorIs(t, err, countries.ErrUnknownVATPrefix, vat)
+	}
+}
+
+func TestVATNumberPrefixes(t *testing.T) {
+	for _, c := range countries.Data.All {
+		prefix := c.Alpha2
+		if prefix == "GR" {
+			prefix = "EL"
+		}
+		_, err := countries.ValidateVATNumber(prefix + "0")
+		if c.EUVATMember() && c.Alpha2 != "MC" {
+			assert.ErrorIs(t, err, countries.ErrInvalidVATNumber, c.Alpha2)
+		} else {
+			assert.ErrorIs(t, err, countries.ErrUnknownVATPrefix, c.Alpha2)
+		}
+	}
+	_, err := countries.ValidateVATNumber("XI0")
+	assert.ErrorIs(t, err, countries.ErrInvalidVATNumber)
+}
+
+func TestVATNumberString(t *testing.T) {
+	v, err := countries.ValidateVATNumber("el 094 259 216")
+	assert.NoError(t, err)
+	assert.Equal(t, "EL094259216", v.String())
+}
+
+func ExampleValidateVATNumber() {
+	v, err := countries.ValidateVATNumber("EL 094 259 216")
+	fmt.Println(v.Country.Alpha2, v.Prefix, v.Number, err)
+	_, err = countries.ValidateVATNumber("IT 00743110158")
+	fmt.Println(err)
+	// Output:
+	// GR EL 094259216 <nil>
+	// invalid VAT number checksum: IT00743110158
+}
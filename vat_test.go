@@ -0,0 +1,120 @@
+package countries_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/pioz/countries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVatRatesDecimals(t *testing.T) {
+	assert.Equal(t, []float64{5.5, 10}, countries.Get("FR").VatRates.Reduced)
+	assert.Equal(t, 2.1, countries.Get("FR").VatRates.SuperReduced)
+	assert.Equal(t, 8.1, countries.Get("CH").VatRates.Standard)
+	assert.Equal(t, []float64{2.6, 3.8}, countries.Get("CH").VatRates.Reduced)
+	assert.Equal(t, 13.5, countries.Get("IE").VatRates.Parking)
+}
+
+func TestCountryVAT(t *testing.T) {
+	tests := []struct {
+		country  string
+		category countries.VATCategory
+		rate     float64
+	}{
+		{"IT", countries.VATCategoryStandard, 22},
+		{"IT", countries.VATCategoryBooks, 4},
+		{"IT", countries.VATCategoryFood, 4},
+		{"IT", countries.VATCategoryEServices, 22},
+		{"IT", countries.VATCategoryPassengerTransport, 10},
+		{"FR", countries.VATCategoryBooks, 5.5},
+		{"DE", countries.VATCategoryFood, 7},
+		{"IE", countries.VATCategoryBooks, 0},
+		{"DK", countries.VATCategoryBooks, 25},
+		{"CH", countries.VATCategoryEServices, 8.1},
+		{"CH", countries.VATCategoryFood, 2.6},
+		{"US", countries.VATCategoryStandard, 0},
+	}
+	for _, tt := range tests {
+		v := countries.Get(tt.country).VAT(tt.category)
+		assert.Equal(t, tt.rate, v.Rate, "%s %s", tt.country, tt.category)
+		assert.Equal(t, tt.country, v.Country.Alpha2)
+		assert.Equal(t, tt.category, v.Category)
+	}
+}
+
+func TestCountryVATRatesAreKnown(t *testing.T) {
+	categories := []countries.VATCategory{
+		countries.VATCategoryBooks,
+		countries.VATCategoryFood,
+		countries.VATCategoryEServices,
+		countries.VATCategoryPassengerTransport,
+	}
+	for _, c := range countries.Data.All {
+		rates := append([]float64{0, c.VatRates.Standard, c.VatRates.SuperReduced, c.VatRates.Parking}, c.VatRates.Reduced...)
+		for _, category := range categories {
+			assert.Contains(t, rates, c.VAT(category).Rate, "%s %s", c.Alpha2, category)
+		}
+	}
+}
+
+func TestVATApply(t *testing.T) {
+	assert.Equal(t, countries.VATAmount{Net: 10000, VAT: 2200, Gross: 12200}, countries.Get("IT").VAT(countries.VATCategoryStandard).Apply(10000))
+	// 5.5% of 1.99 is 0.10945.
+	assert.Equal(t, countries.VATAmount{Net: 199, VAT: 11, Gross: 210}, countries.Get("FR").VAT(countries.VATCategoryBooks).Apply(199))
+	// 8.1% of 0.50 is 0.0405.
+	assert.Equal(t, countries.VATAmount{Net: 50, VAT: 4, Gross: 54}, countries.Get("CH").VAT(countries.VATCategoryStandard).Apply(50))
+	// 8.1% of 0.90 is 0.0729.
+	assert.Equal(t, countries.VATAmount{Net: 90, VAT: 7, Gross: 97}, countries.Get("CH").VAT(countries.VATCategoryStandard).Apply(90))
+	// 22% of 0.25 is 0.055, rounded half away from zero.
+	assert.Equal(t, countries.VATAmount{Net: 25, VAT: 6, Gross: 31}, countries.Get("IT").VAT(countries.VATCategoryStandard).Apply(25))
+	assert.Equal(t, countries.VATAmount{Net: -25, VAT: -6, Gross: -31}, countries.Get("IT").VAT(countries.VATCategoryStandard).Apply(-25))
+	assert.Equal(t, countries.VATAmount{Net: 1000, VAT: 0, Gross: 1000}, countries.Get("IE").VAT(countries.VATCategoryBooks).Apply(1000))
+	assert.Equal(t, countries.VATAmount{Net: 1000, VAT: 0, Gross: 1000}, countries.Get("US").VAT(countries.VATCategoryStandard).Apply(1000))
+	// No overflow with large amounts whose gross amount fits in an int64.
+	assert.Equal(t, int64(1100000000000000000), countries.Get("IT").VAT(countries.VATCategoryStandard).Apply(5000000000000000000).VAT)
+	hu := countries.Get("HU").VAT(countries.VATCategoryStandard)
+	net := int64(math.MaxInt64 / 1.27)
+	a := hu.Apply(net)
+	assert.Greater(t, a.Gross, net)
+	assert.Equal(t, a.Gross, a.Net+a.VAT)
+	a = hu.Apply(-net)
+	assert.Less(t, a.Gross, -net)
+	// Amounts not fitting in an int64 are saturated.
+	assert.Equal(t, countries.VATAmount{Net: math.MaxInt64, VAT: 2490310449950789468, Gross: math.MaxInt64}, hu.Apply(math.MaxInt64))
+	assert.Equal(t, countries.VATAmount{Net: math.MinInt64, VAT: -2490310449950789468, Gross: math.MinInt64}, hu.Apply(math.MinInt64))
+	assert.Equal(t, countries.VATAmount{Net: math.MaxInt64, VAT: math.MaxInt64, Gross: math.MaxInt64}, countries.VAT{Rate: 300}.Apply(math.MaxInt64))
+	assert.Equal(t, countries.VATAmount{Net: math.MinInt64, VAT: math.MinInt64, Gross: math.MinInt64}, countries.VAT{Rate: 300}.Apply(math.MinInt64))
+	assert.Equal(t, countries.VATAmount{Net: 1000000, VAT: math.MaxInt64, Gross: math.MaxInt64}, countries.VAT{Rate: 1e30}.Apply(1000000))
+	assert.Equal(t, countries.VATAmount{Net: 10000, VAT: math.MaxInt64 - 10000, Gross: math.MaxInt64}, countries.VAT{Rate: math.Inf(1)}.ExtractFromGross(math.MaxInt64))
+	assert.Equal(t, countries.VATAmount{Net: 100, VAT: 0, Gross: 100}, countries.VAT{Rate: math.NaN()}.Apply(100))
+	assert.Equal(t, countries.VATAmount{Net: 100, VAT: 0, Gross: 100}, countries.VAT{Rate: -10}.Apply(100))
+	assert.Equal(t, countries.VATAmount{Net: 25, VAT: 75, Gross: 100}, countries.VAT{Rate: 300}.ExtractFromGross(100))
+}
+
+func TestVATExtractFromGross(t *testing.T) {
+	assert.Equal(t, countries.VATAmount{Net: 10000, VAT: 2200, Gross: 12200}, countries.Get("IT").VAT(countries.VATCategoryStandard).ExtractFromGross(12200))
+	// 9.99 / 1.055 is 9.4692, the VAT is 0.5208.
+	assert.Equal(t, countries.VATAmount{Net: 947, VAT: 52, Gross: 999}, countries.Get("FR").VAT(countries.VATCategoryBooks).ExtractFromGross(999))
+	assert.Equal(t, countries.VATAmount{Net: -947, VAT: -52, Gross: -999}, countries.Get("FR").VAT(countries.VATCategoryBooks).ExtractFromGross(-999))
+	assert.Equal(t, countries.VATAmount{Net: 1000, VAT: 0, Gross: 1000}, countries.Get("GB").VAT(countries.VATCategoryFood).ExtractFromGross(1000))
+
+	v := countries.Get("CH").VAT(countries.VATCategoryStandard)
+	for gross := int64(0); gross < 10000; gross++ {
+		a := v.ExtractFromGross(gross)
+		assert.Equal(t, gross, a.Net+a.VAT)
+		assert.InDelta(t, gross, v.Apply(a.Net).Gross, 1)
+	}
+}
+
+func ExampleVAT_Apply() {
+	v := countries.Get("FR").VAT(countries.VATCategoryBooks)
+	fmt.Println(v.Rate)
+	fmt.Printf("%+v\n", v.Apply(1999))
+	fmt.Printf("%+v\n", v.ExtractFromGross(1999))
+	// Output:
+	// 5.5
+	// {Net:1999 VAT:110 Gross:2109}
+	// {Net:1895 VAT:104 Gross:1999}
+}